@@ -0,0 +1,61 @@
+// Commande i18n-check : vérifie la couverture des traductions (clés
+// utilisées par les templates et le code, absentes d'une langue, et clés
+// traduites que rien n'utilise).
+//
+//	go run ./cmd/i18n-check [-locales ./internal/i18n/locales] [-strict]
+//
+// Le code de sortie est 1 si une traduction manque (ou, avec -strict, si
+// une clé est inutilisée).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"wedding-web/internal/i18n"
+)
+
+func main() {
+	templatesDir := flag.String("templates", "./web/templates", "Répertoire des templates")
+	sourceDir := flag.String("src", "./internal", "Répertoire du code Go")
+	localesDir := flag.String("locales", "", "Répertoire de catalogues <lang>.json (vide : catalogues intégrés)")
+	strict := flag.Bool("strict", false, "Échouer aussi sur les clés inutilisées")
+	flag.Parse()
+
+	if err := i18n.Load(*localesDir, false); err != nil {
+		fmt.Fprintf(os.Stderr, "Erreur lors du chargement des traductions: %v\n", err)
+		os.Exit(2)
+	}
+
+	report, err := i18n.CheckCoverage(*templatesDir, *sourceDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Erreur lors de l'analyse: %v\n", err)
+		os.Exit(2)
+	}
+
+	langs := make([]string, 0, len(report.Missing))
+	for lang := range report.Missing {
+		langs = append(langs, string(lang))
+	}
+	sort.Strings(langs)
+	for _, lang := range langs {
+		keys := report.Missing[i18n.Lang(lang)]
+		fmt.Printf("❌ %s : %d traduction(s) manquante(s)\n", lang, len(keys))
+		for _, key := range keys {
+			fmt.Printf("   - %s\n", key)
+		}
+	}
+	if len(report.Unused) > 0 {
+		fmt.Printf("⚠️  %d clé(s) inutilisée(s)\n", len(report.Unused))
+		for _, key := range report.Unused {
+			fmt.Printf("   - %s\n", key)
+		}
+	}
+
+	if !report.OK() || (*strict && len(report.Unused) > 0) {
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Traductions complètes (%d langues)\n", len(i18n.Locales()))
+}
@@ -3,17 +3,32 @@ package main
 import (
 	"fmt"
 	"log"
+	"net/mail"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Config contient toute la configuration de l'application.
 type Config struct {
-	Server   ServerConfig   `yaml:"server"`
-	Security SecurityConfig `yaml:"security"`
-	RSVP     RSVPConfig     `yaml:"rsvp"`
-	Admin    AdminConfig    `yaml:"admin"`
+	Server            ServerConfig            `yaml:"server"`
+	Security          SecurityConfig          `yaml:"security"`
+	RSVP              RSVPConfig              `yaml:"rsvp"`
+	Invitations       InvitationsConfig       `yaml:"invitations"`
+	Carpool           CarpoolConfig           `yaml:"carpool"`
+	Songs             SongsConfig             `yaml:"songs"`
+	Guestbook         GuestbookConfig         `yaml:"guestbook"`
+	Registry          RegistryConfig          `yaml:"registry"`
+	Photos            PhotosConfig            `yaml:"photos"`
+	Seating           SeatingConfig           `yaml:"seating"`
+	Thanks            ThanksConfig            `yaml:"thanks"`
+	Calendar          CalendarConfig          `yaml:"calendar"`
+	Content           ContentConfig           `yaml:"content"`
+	I18n              I18nConfig              `yaml:"i18n"`
+	Notifications     NotificationsConfig     `yaml:"notifications"`
+	ReminderCampaigns ReminderCampaignsConfig `yaml:"reminder_campaigns"`
+	Admin             AdminConfig             `yaml:"admin"`
 }
 
 // ServerConfig contient la configuration du serveur HTTP.
@@ -42,6 +57,190 @@ type RSVPConfig struct {
 	StoragePath string `yaml:"storage_path"`
 }
 
+// InvitationsConfig contient la configuration des invitations personnelles.
+type InvitationsConfig struct {
+	StoragePath string `yaml:"storage_path"`
+}
+
+// CarpoolConfig contient la configuration du covoiturage.
+type CarpoolConfig struct {
+	StoragePath string `yaml:"storage_path"`
+}
+
+// SongsConfig contient la configuration des suggestions musicales.
+type SongsConfig struct {
+	StoragePath string `yaml:"storage_path"`
+}
+
+// GuestbookConfig contient la configuration du livre d'or.
+type GuestbookConfig struct {
+	StoragePath string `yaml:"storage_path"`
+}
+
+// RegistryConfig contient la configuration de la liste de mariage.
+type RegistryConfig struct {
+	StoragePath string `yaml:"storage_path"`
+}
+
+// PhotosConfig contient la configuration des photos des invités.
+type PhotosConfig struct {
+	StoragePath string `yaml:"storage_path"`  // Métadonnées (fichier chiffré)
+	Directory   string `yaml:"directory"`     // Photos et miniatures (hors du répertoire static)
+	MaxUploadMB int    `yaml:"max_upload_mb"` // Taille maximale d'un envoi (plusieurs photos)
+}
+
+// SeatingConfig contient la configuration du plan de table.
+type SeatingConfig struct {
+	StoragePath string `yaml:"storage_path"`
+}
+
+// ThanksConfig contient la configuration du suivi des remerciements.
+type ThanksConfig struct {
+	StoragePath string `yaml:"storage_path"`
+}
+
+// CalendarConfig contient la configuration des exports calendrier.
+type CalendarConfig struct {
+	Reminders []string `yaml:"reminders"` // Rappels par défaut avant chaque événement (ex: "24h", "2h")
+}
+
+// ReminderDurations retourne les rappels par défaut sous forme de durées.
+func (c CalendarConfig) ReminderDurations() ([]time.Duration, error) {
+	durations := make([]time.Duration, 0, len(c.Reminders))
+	for _, reminder := range c.Reminders {
+		d, err := time.ParseDuration(reminder)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("rappel calendrier invalide: %q", reminder)
+		}
+		durations = append(durations, d)
+	}
+	return durations, nil
+}
+
+// ContentConfig contient la configuration des contenus rédigés.
+type ContentConfig struct {
+	Dir string `yaml:"dir"` // Répertoire des fichiers de contenu (infos.<lang>.yaml)
+}
+
+// I18nConfig contient la configuration des traductions.
+type I18nConfig struct {
+	LocalesDir  string `yaml:"locales_dir"` // Catalogues <lang>.json (vide : catalogues intégrés au binaire)
+	Negotiation string `yaml:"negotiation"` // accept_language : langue du navigateur ; none : langue par défaut
+	URLPrefix   bool   `yaml:"url_prefix"`  // Adresses préfixées par la langue (/de/planning)
+}
+
+// NotificationsConfig contient la configuration des emails envoyés aux
+// mariés et aux invités.
+type NotificationsConfig struct {
+	Enabled            bool       `yaml:"enabled"`
+	Recipients         []string   `yaml:"recipients"`          // Adresses des mariés (vide : pas de notification des réponses)
+	GuestConfirmations bool       `yaml:"guest_confirmations"` // Confirmer leur réponse aux invités qui laissent leur adresse
+	Mode               string     `yaml:"mode"`                // instant : un email par réponse ; digest : un récapitulatif quotidien
+	DigestTime         string     `yaml:"digest_time"`         // Heure du récapitulatif quotidien (ex: "08:00")
+	QueuePath          string     `yaml:"queue_path"`          // File des emails en attente (fichier chiffré)
+	TemplatesDir       string     `yaml:"templates_dir"`       // Templates des emails (*.txt)
+	MaxAttempts        int        `yaml:"max_attempts"`        // Tentatives d'envoi avant abandon d'un email
+	Transport          string     `yaml:"transport"`           // smtp : envoi par le serveur SMTP ; file : fichiers .eml (développement)
+	DumpDir            string     `yaml:"dump_dir"`            // Répertoire des fichiers .eml (transport file)
+	SMTP               SMTPConfig `yaml:"smtp"`
+}
+
+// DigestOffset retourne l'heure du récapitulatif quotidien, depuis minuit.
+func (c NotificationsConfig) DigestOffset() (time.Duration, error) {
+	at, err := timeOfDay(c.DigestTime)
+	if err != nil {
+		return 0, fmt.Errorf("heure du récapitulatif invalide: %q", c.DigestTime)
+	}
+	return at, nil
+}
+
+// Validate valide la configuration des notifications.
+func (c NotificationsConfig) Validate() error {
+	if len(c.Recipients) == 0 && !c.GuestConfirmations {
+		return fmt.Errorf("notifications.recipients est obligatoire si les confirmations aux invités sont désactivées")
+	}
+	for _, recipient := range c.Recipients {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			return fmt.Errorf("notifications.recipients: adresse invalide %q", recipient)
+		}
+	}
+	if c.Mode != "instant" && c.Mode != "digest" {
+		return fmt.Errorf("notifications.mode doit valoir instant ou digest (reçu %q)", c.Mode)
+	}
+	if _, err := c.DigestOffset(); err != nil {
+		return err
+	}
+	switch c.Transport {
+	case "smtp":
+		if c.SMTP.Host == "" {
+			return fmt.Errorf("notifications.smtp.host est obligatoire avec le transport smtp")
+		}
+	case "file":
+		if c.DumpDir == "" {
+			return fmt.Errorf("notifications.dump_dir est obligatoire avec le transport file")
+		}
+	default:
+		return fmt.Errorf("notifications.transport doit valoir smtp ou file (reçu %q)", c.Transport)
+	}
+	if _, err := mail.ParseAddress(c.SMTP.From); err != nil {
+		return fmt.Errorf("notifications.smtp.from: expéditeur invalide %q", c.SMTP.From)
+	}
+	return nil
+}
+
+// ReminderCampaignsConfig contient la configuration des relances des invitations
+// sans réponse.
+type ReminderCampaignsConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	DaysBefore  []int  `yaml:"days_before"` // Campagnes, en jours avant la date limite de réponse (ex: [21, 7, 2])
+	SendTime    string `yaml:"send_time"`   // Heure d'envoi des campagnes (ex: "10:00")
+	StoragePath string `yaml:"storage_path"`
+}
+
+// SendOffset retourne l'heure d'envoi des campagnes, depuis minuit.
+func (c ReminderCampaignsConfig) SendOffset() (time.Duration, error) {
+	at, err := timeOfDay(c.SendTime)
+	if err != nil {
+		return 0, fmt.Errorf("heure des relances invalide: %q", c.SendTime)
+	}
+	return at, nil
+}
+
+// Validate valide la configuration des relances.
+func (c ReminderCampaignsConfig) Validate() error {
+	if len(c.DaysBefore) == 0 {
+		return fmt.Errorf("reminder_campaigns.days_before est obligatoire si les relances sont activées")
+	}
+	seen := make(map[int]bool, len(c.DaysBefore))
+	for _, days := range c.DaysBefore {
+		if days < 1 || days > 365 || seen[days] {
+			return fmt.Errorf("reminder_campaigns.days_before: valeur invalide ou en double %d", days)
+		}
+		seen[days] = true
+	}
+	_, err := c.SendOffset()
+	return err
+}
+
+// timeOfDay convertit une heure "15:04" en durée depuis minuit.
+func timeOfDay(value string) (time.Duration, error) {
+	at, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute, nil
+}
+
+// SMTPConfig contient la configuration du serveur d'envoi des emails.
+type SMTPConfig struct {
+	Host           string `yaml:"host"`
+	Port           int    `yaml:"port"`
+	Username       string `yaml:"username"`
+	Password       string `yaml:"password"`
+	PasswordEnvVar string `yaml:"password_env_var"`
+	From           string `yaml:"from"` // Expéditeur (ex: "Marie & Paul <mariage@example.com>")
+}
+
 // AdminConfig contient la configuration de la page admin.
 type AdminConfig struct {
 	Enabled        bool   `yaml:"enabled"`
@@ -76,6 +275,104 @@ func (c *Config) Defaults() {
 	if c.RSVP.StoragePath == "" {
 		c.RSVP.StoragePath = "./rsvp_data/reservations.json"
 	}
+
+	// Invitations defaults
+	if c.Invitations.StoragePath == "" {
+		c.Invitations.StoragePath = "./rsvp_data/invitations.json"
+	}
+
+	// Carpool defaults
+	if c.Carpool.StoragePath == "" {
+		c.Carpool.StoragePath = "./rsvp_data/carpool.json"
+	}
+
+	// Songs defaults
+	if c.Songs.StoragePath == "" {
+		c.Songs.StoragePath = "./rsvp_data/songs.json"
+	}
+
+	// Guestbook defaults
+	if c.Guestbook.StoragePath == "" {
+		c.Guestbook.StoragePath = "./rsvp_data/guestbook.json"
+	}
+
+	// Registry defaults
+	if c.Registry.StoragePath == "" {
+		c.Registry.StoragePath = "./rsvp_data/registry.json"
+	}
+
+	// Photos defaults
+	if c.Photos.StoragePath == "" {
+		c.Photos.StoragePath = "./rsvp_data/photos.json"
+	}
+	if c.Photos.Directory == "" {
+		c.Photos.Directory = "./rsvp_data/photos"
+	}
+	if c.Photos.MaxUploadMB <= 0 {
+		c.Photos.MaxUploadMB = 50
+	}
+
+	// Seating defaults
+	if c.Seating.StoragePath == "" {
+		c.Seating.StoragePath = "./rsvp_data/seating.json"
+	}
+
+	// Thanks defaults
+	if c.Thanks.StoragePath == "" {
+		c.Thanks.StoragePath = "./rsvp_data/thanks.json"
+	}
+
+	// Calendar defaults : la veille et deux heures avant
+	if c.Calendar.Reminders == nil {
+		c.Calendar.Reminders = []string{"24h", "2h"}
+	}
+
+	// Content defaults
+	if c.Content.Dir == "" {
+		c.Content.Dir = "./web/content"
+	}
+
+	// I18n defaults : langue du navigateur pour une première visite
+	if c.I18n.Negotiation == "" {
+		c.I18n.Negotiation = "accept_language"
+	}
+
+	// Notifications defaults : un email par réponse
+	if c.Notifications.Mode == "" {
+		c.Notifications.Mode = "instant"
+	}
+	if c.Notifications.DigestTime == "" {
+		c.Notifications.DigestTime = "08:00"
+	}
+	if c.Notifications.QueuePath == "" {
+		c.Notifications.QueuePath = "./rsvp_data/email_queue.json"
+	}
+	if c.Notifications.TemplatesDir == "" {
+		c.Notifications.TemplatesDir = "./web/templates/emails"
+	}
+	if c.Notifications.MaxAttempts <= 0 {
+		c.Notifications.MaxAttempts = 10
+	}
+	if c.Notifications.Transport == "" {
+		c.Notifications.Transport = "smtp"
+	}
+	if c.Notifications.DumpDir == "" {
+		c.Notifications.DumpDir = "./rsvp_data/emails"
+	}
+	if c.Notifications.SMTP.Port == 0 {
+		c.Notifications.SMTP.Port = 587
+	}
+
+	// Relances defaults : trois semaines, une semaine et deux jours avant la date limite
+	if c.ReminderCampaigns.DaysBefore == nil {
+		c.ReminderCampaigns.DaysBefore = []int{21, 7, 2}
+	}
+	if c.ReminderCampaigns.SendTime == "" {
+		c.ReminderCampaigns.SendTime = "10:00"
+	}
+	if c.ReminderCampaigns.StoragePath == "" {
+		c.ReminderCampaigns.StoragePath = "./rsvp_data/reminder_campaigns.json"
+	}
 }
 
 // LoadFromEnv charge les secrets depuis les variables d'environnement.
@@ -99,6 +396,13 @@ func (c *Config) LoadFromEnv() error {
 		}
 	}
 
+	// Charger le mot de passe SMTP depuis ENV si spécifié
+	if c.Notifications.SMTP.PasswordEnvVar != "" {
+		if password := os.Getenv(c.Notifications.SMTP.PasswordEnvVar); password != "" {
+			c.Notifications.SMTP.Password = password
+		}
+	}
+
 	// Override avec PORT si défini
 	if port := os.Getenv("PORT"); port != "" {
 		c.Server.Port = port
@@ -124,6 +428,33 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Les rappels du calendrier doivent être des durées valides
+	if _, err := c.Calendar.ReminderDurations(); err != nil {
+		return err
+	}
+
+	// Choix de la langue des visiteurs
+	if c.I18n.Negotiation != "accept_language" && c.I18n.Negotiation != "none" {
+		return fmt.Errorf("i18n.negotiation doit valoir accept_language ou none (reçu %q)", c.I18n.Negotiation)
+	}
+
+	// Les notifications ont besoin de destinataires et d'un serveur SMTP
+	if c.Notifications.Enabled {
+		if err := c.Notifications.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// Les relances partent par la file des notifications
+	if c.ReminderCampaigns.Enabled {
+		if !c.Notifications.Enabled {
+			return fmt.Errorf("reminder_campaigns nécessite notifications.enabled")
+		}
+		if err := c.ReminderCampaigns.Validate(); err != nil {
+			return err
+		}
+	}
+
 	// Si admin est activé, username et password sont obligatoires
 	if c.Admin.Enabled {
 		if c.Admin.Username == "" || c.Admin.Password == "" {
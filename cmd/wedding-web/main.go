@@ -6,12 +6,17 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	"wedding-web/internal/adapters/content"
 	"wedding-web/internal/adapters/http"
+	"wedding-web/internal/adapters/mail"
 	"wedding-web/internal/adapters/storage"
 	"wedding-web/internal/application"
+	"wedding-web/internal/domain/ports"
+	"wedding-web/internal/i18n"
 )
 
 func main() {
@@ -29,6 +34,16 @@ func main() {
 	log.Printf("🌍 Environnement: %s", appConfig.Server.Environment)
 	log.Printf("🚪 Port: %s", appConfig.Server.Port)
 
+	// Charger les traductions (rechargées à chaud en dev)
+	if err := i18n.Load(appConfig.I18n.LocalesDir, appConfig.IsDev()); err != nil {
+		log.Fatalf("Erreur lors du chargement des traductions: %v", err)
+	}
+	i18n.Configure(i18n.Options{
+		AcceptLanguage: appConfig.I18n.Negotiation == "accept_language",
+		URLPrefix:      appConfig.I18n.URLPrefix,
+		ReportMissing:  appConfig.IsDev(),
+	})
+
 	// Initialiser les services
 	services, err := initializeServices(appConfig)
 	if err != nil {
@@ -45,9 +60,21 @@ func main() {
 		services.planningService,
 		services.infoService,
 		services.calendarService,
+		services.invitationService,
+		services.accommodationService,
+		services.carpoolService,
+		services.songService,
+		services.guestbookService,
+		services.registryService,
+		services.photoService,
+		services.seatingService,
+		services.thankYouService,
+		services.notificationService,
+		services.reminderService,
 		services.csrfManager,
 		templatesDir,
 		appConfig.IsDev(),
+		appConfig.Server.BaseURL,
 		appConfig.Admin.Username,
 		appConfig.Admin.Password,
 	)
@@ -72,6 +99,7 @@ func main() {
 		},
 		RateLimitPerMinute: appConfig.Security.RateLimitPerMinute,
 		MaxBodySize:        1 << 20, // 1 MB
+		MaxUploadSize:      int64(appConfig.Photos.MaxUploadMB) << 20,
 		StaticDir:          staticDir,
 		TemplatesDir:       templatesDir,
 	}
@@ -87,6 +115,16 @@ func main() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
+	// Tâches de fond (file d'envoi des emails, relances), arrêtées avec le serveur
+	background, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	if services.notificationService != nil {
+		go services.notificationService.Run(background)
+	}
+	if services.reminderService != nil {
+		go services.reminderService.Run(background)
+	}
+
 	// Démarrer le serveur dans une goroutine
 	go func() {
 		if err := server.Start(); err != nil {
@@ -99,6 +137,7 @@ func main() {
 
 	// Arrêt propre du serveur
 	log.Println("Signal d'arrêt reçu, fermeture du serveur...")
+	stopBackground()
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -111,11 +150,22 @@ func main() {
 
 // Services contient tous les services de l'application
 type Services struct {
-	rsvpService     *application.RSVPService
-	planningService *application.PlanningService
-	infoService     *application.InfoService
-	calendarService *application.CalendarService
-	csrfManager     *http.CSRFManager
+	rsvpService          *application.RSVPService
+	planningService      *application.PlanningService
+	infoService          *application.InfoService
+	calendarService      *application.CalendarService
+	invitationService    *application.InvitationService
+	accommodationService *application.AccommodationService
+	carpoolService       *application.CarpoolService
+	songService          *application.SongService
+	guestbookService     *application.GuestbookService
+	registryService      *application.RegistryService
+	photoService         *application.PhotoService
+	seatingService       *application.SeatingService
+	thankYouService      *application.ThankYouService
+	notificationService  *application.NotificationService     // nil si les notifications sont désactivées
+	reminderService      *application.ReminderCampaignService // nil si les relances sont désactivées
+	csrfManager          *http.CSRFManager
 }
 
 // initializeServices initialise tous les services
@@ -129,24 +179,205 @@ func initializeServices(config *Config) (*Services, error) {
 		return nil, err
 	}
 
+	// Storage pour les invitations
+	invitationStorage, err := storage.NewEncryptedInvitationStorage(
+		config.Invitations.StoragePath,
+		config.Security.EncryptionKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Storage pour le covoiturage
+	carpoolStorage, err := storage.NewEncryptedCarpoolStorage(
+		config.Carpool.StoragePath,
+		config.Security.EncryptionKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Storage pour les suggestions musicales
+	songStorage, err := storage.NewEncryptedSongStorage(
+		config.Songs.StoragePath,
+		config.Security.EncryptionKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Storage pour le livre d'or
+	guestbookStorage, err := storage.NewEncryptedGuestbookStorage(
+		config.Guestbook.StoragePath,
+		config.Security.EncryptionKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Storage pour la liste de mariage
+	registryStorage, err := storage.NewEncryptedRegistryStorage(
+		config.Registry.StoragePath,
+		config.Security.EncryptionKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Storage pour les photos des invités (métadonnées chiffrées, images dans un répertoire)
+	photoStorage, err := storage.NewEncryptedPhotoStorage(
+		config.Photos.StoragePath,
+		config.Security.EncryptionKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	photoDirectory, err := storage.NewPhotoDirectory(config.Photos.Directory)
+	if err != nil {
+		return nil, err
+	}
+
+	// Storage pour le plan de table
+	seatingStorage, err := storage.NewEncryptedSeatingStorage(
+		config.Seating.StoragePath,
+		config.Security.EncryptionKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Storage pour le suivi des remerciements
+	thankYouStorage, err := storage.NewEncryptedThankYouStorage(
+		config.Thanks.StoragePath,
+		config.Security.EncryptionKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Services métier
-	rsvpService := application.NewRSVPService(rsvpStorage)
 	planningService := application.NewPlanningService()
-	infoService := application.NewInfoService()
-	calendarService := application.NewCalendarService()
+	rsvpService := application.NewRSVPService(rsvpStorage, planningService)
+	infoService := application.NewInfoService(content.NewYAMLPracticalInfoSource(config.Content.Dir))
+	reminders, err := config.Calendar.ReminderDurations()
+	if err != nil {
+		return nil, err
+	}
+	calendarService := application.NewCalendarService(reminders)
+	invitationService := application.NewInvitationService(invitationStorage)
+	accommodationService := application.NewAccommodationService(
+		content.NewYAMLAccommodationSource(config.Content.Dir),
+		rsvpService,
+	)
+
+	carpoolService := application.NewCarpoolService(carpoolStorage, planningService, rsvpService)
+	songService := application.NewSongService(songStorage)
+	guestbookService := application.NewGuestbookService(guestbookStorage)
+	registryService := application.NewRegistryService(registryStorage)
+	photoService := application.NewPhotoService(photoStorage, photoDirectory)
+	seatingService := application.NewSeatingService(seatingStorage, rsvpService)
+	thankYouService := application.NewThankYouService(thankYouStorage, rsvpService, invitationService, registryService)
+
+	// Notifications des mariés par email (optionnelles)
+	var notificationService *application.NotificationService
+	if config.Notifications.Enabled {
+		notificationService, err = initializeNotifications(config, calendarService)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("✉️  Notifications des réponses activées (%s, transport %s)", config.Notifications.Mode, config.Notifications.Transport)
+	}
+
+	// Relances des invitations sans réponse (optionnelles)
+	var reminderService *application.ReminderCampaignService
+	if config.ReminderCampaigns.Enabled {
+		reminderStorage, err := storage.NewEncryptedReminderCampaignStorage(
+			config.ReminderCampaigns.StoragePath,
+			config.Security.EncryptionKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+		sendTime, err := config.ReminderCampaigns.SendOffset()
+		if err != nil {
+			return nil, err
+		}
+		reminderService = application.NewReminderCampaignService(
+			reminderStorage,
+			invitationService,
+			rsvpService,
+			planningService,
+			notificationService,
+			config.ReminderCampaigns.DaysBefore,
+			sendTime,
+			strings.TrimSuffix(config.Server.BaseURL, "/"),
+		)
+		log.Printf("📣 Relances des invitations sans réponse activées (%d campagne(s) à %s)", len(config.ReminderCampaigns.DaysBefore), config.ReminderCampaigns.SendTime)
+	}
 
 	// CSRF Manager
 	csrfManager := http.NewCSRFManager()
 
 	return &Services{
-		rsvpService:     rsvpService,
-		planningService: planningService,
-		infoService:     infoService,
-		calendarService: calendarService,
-		csrfManager:     csrfManager,
+		rsvpService:          rsvpService,
+		planningService:      planningService,
+		infoService:          infoService,
+		calendarService:      calendarService,
+		invitationService:    invitationService,
+		accommodationService: accommodationService,
+		carpoolService:       carpoolService,
+		songService:          songService,
+		guestbookService:     guestbookService,
+		registryService:      registryService,
+		photoService:         photoService,
+		seatingService:       seatingService,
+		thankYouService:      thankYouService,
+		notificationService:  notificationService,
+		reminderService:      reminderService,
+		csrfManager:          csrfManager,
 	}, nil
 }
 
+// initializeNotifications initialise l'envoi des emails aux mariés et aux
+// invités
+func initializeNotifications(config *Config, calendarService *application.CalendarService) (*application.NotificationService, error) {
+	queueStorage, err := storage.NewEncryptedEmailQueueStorage(
+		config.Notifications.QueuePath,
+		config.Security.EncryptionKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var mailer ports.Mailer
+	smtp := config.Notifications.SMTP
+	if config.Notifications.Transport == "file" {
+		mailer, err = mail.NewFileMailer(config.Notifications.DumpDir, smtp.From)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		mailer = mail.NewSMTPMailer(smtp.Host, smtp.Port, smtp.Username, smtp.Password, smtp.From)
+	}
+
+	digestTime, err := config.Notifications.DigestOffset()
+	if err != nil {
+		return nil, err
+	}
+	return application.NewNotificationService(
+		mailer,
+		queueStorage,
+		calendarService,
+		config.Notifications.TemplatesDir,
+		config.Notifications.Recipients,
+		config.Notifications.GuestConfirmations,
+		application.NotificationMode(config.Notifications.Mode),
+		digestTime,
+		config.Notifications.MaxAttempts,
+		strings.TrimSuffix(config.Server.BaseURL, "/"),
+	)
+}
+
 // getEnv récupère une variable d'environnement avec une valeur par défaut
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
@@ -0,0 +1,96 @@
+package content
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+	"wedding-web/internal/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+// accommodationsFile est le format YAML d'un fichier accommodations.<lang>.yaml
+type accommodationsFile struct {
+	Accommodations []struct {
+		ID            string  `yaml:"id"`
+		Name          string  `yaml:"name"`
+		Kind          string  `yaml:"kind"`
+		Address       string  `yaml:"address"`
+		DistanceKm    float64 `yaml:"distance_km"`
+		PriceRange    string  `yaml:"price_range"`
+		Website       string  `yaml:"website"`
+		Phone         string  `yaml:"phone"`
+		BookingCode   string  `yaml:"booking_code"`
+		RoomsBlocked  int     `yaml:"rooms_blocked"`
+		BlockDeadline string  `yaml:"block_deadline"` // Format AAAA-MM-JJ
+		Notes         string  `yaml:"notes"`
+	} `yaml:"accommodations"`
+}
+
+// YAMLAccommodationSource lit l'annuaire des hébergements depuis des fichiers
+// YAML par langue (accommodations.fr.yaml, accommodations.de.yaml...)
+type YAMLAccommodationSource struct {
+	dir   string
+	cache *fileCache[[]domain.Accommodation]
+}
+
+// NewYAMLAccommodationSource crée une source lisant les fichiers du répertoire donné
+func NewYAMLAccommodationSource(dir string) *YAMLAccommodationSource {
+	return &YAMLAccommodationSource{
+		dir:   dir,
+		cache: newFileCache[[]domain.Accommodation](),
+	}
+}
+
+// LoadAccommodations retourne les hébergements rédigés dans la langue donnée,
+// dans l'ordre du fichier
+func (s *YAMLAccommodationSource) LoadAccommodations(lang string) ([]domain.Accommodation, error) {
+	path := filepath.Join(s.dir, fmt.Sprintf("accommodations.%s.yaml", filepath.Base(lang)))
+	return s.cache.load(path, parseAccommodations)
+}
+
+// parseAccommodations lit et valide le fichier des hébergements
+func parseAccommodations(path string) ([]domain.Accommodation, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file accommodationsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("erreur parsing %s: %w", path, err)
+	}
+
+	accommodations := make([]domain.Accommodation, 0, len(file.Accommodations))
+	for _, entry := range file.Accommodations {
+		accommodation := domain.Accommodation{
+			ID:           entry.ID,
+			Name:         entry.Name,
+			Kind:         entry.Kind,
+			Address:      entry.Address,
+			DistanceKm:   entry.DistanceKm,
+			PriceRange:   entry.PriceRange,
+			Website:      entry.Website,
+			Phone:        entry.Phone,
+			BookingCode:  entry.BookingCode,
+			RoomsBlocked: entry.RoomsBlocked,
+			Notes:        entry.Notes,
+		}
+
+		if entry.BlockDeadline != "" {
+			deadline, err := time.Parse("2006-01-02", entry.BlockDeadline)
+			if err != nil {
+				return nil, fmt.Errorf("%s: date block_deadline invalide pour %q: %w", path, entry.Name, err)
+			}
+			accommodation.BlockDeadline = deadline
+		}
+
+		if err := accommodation.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %q: %w", path, entry.Name, err)
+		}
+		accommodations = append(accommodations, accommodation)
+	}
+
+	return accommodations, nil
+}
@@ -0,0 +1,52 @@
+package content
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// cachedFile mémorise le résultat de l'analyse d'un fichier et sa date de modification
+type cachedFile[T any] struct {
+	modTime time.Time
+	value   T
+}
+
+// fileCache évite de relire un fichier de contenu tant qu'il n'a pas été
+// modifié : une modification est prise en compte sans redémarrage
+type fileCache[T any] struct {
+	mu      sync.Mutex
+	entries map[string]cachedFile[T]
+}
+
+// newFileCache crée un cache vide
+func newFileCache[T any]() *fileCache[T] {
+	return &fileCache[T]{
+		entries: make(map[string]cachedFile[T]),
+	}
+}
+
+// load retourne le contenu analysé du fichier, en le relisant s'il a changé
+func (c *fileCache[T]) load(path string, parse func(path string) (T, error)) (T, error) {
+	var zero T
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return zero, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.entries[path]; ok && cached.modTime.Equal(stat.ModTime()) {
+		return cached.value, nil
+	}
+
+	value, err := parse(path)
+	if err != nil {
+		return zero, err
+	}
+
+	c.entries[path] = cachedFile[T]{modTime: stat.ModTime(), value: value}
+	return value, nil
+}
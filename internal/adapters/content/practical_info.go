@@ -0,0 +1,73 @@
+package content
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"wedding-web/internal/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+// practicalInfoFile est le format YAML d'un fichier infos.<lang>.yaml
+type practicalInfoFile struct {
+	Sections []struct {
+		ID       string   `yaml:"id"`
+		Title    string   `yaml:"title"`
+		Icon     string   `yaml:"icon"`
+		Body     string   `yaml:"body"`
+		Audience []string `yaml:"audience"`
+	} `yaml:"sections"`
+}
+
+// YAMLPracticalInfoSource lit les infos pratiques depuis des fichiers YAML
+// par langue (infos.fr.yaml, infos.de.yaml...). Un fichier modifié est
+// relu automatiquement, sans redémarrage.
+type YAMLPracticalInfoSource struct {
+	dir   string
+	cache *fileCache[*domain.PracticalInfo]
+}
+
+// NewYAMLPracticalInfoSource crée une source lisant les fichiers du répertoire donné
+func NewYAMLPracticalInfoSource(dir string) *YAMLPracticalInfoSource {
+	return &YAMLPracticalInfoSource{
+		dir:   dir,
+		cache: newFileCache[*domain.PracticalInfo](),
+	}
+}
+
+// LoadPracticalInfo retourne les infos pratiques rédigées dans la langue donnée
+func (s *YAMLPracticalInfoSource) LoadPracticalInfo(lang string) (*domain.PracticalInfo, error) {
+	path := filepath.Join(s.dir, fmt.Sprintf("infos.%s.yaml", filepath.Base(lang)))
+	return s.cache.load(path, parsePracticalInfo)
+}
+
+// parsePracticalInfo lit et valide un fichier d'infos pratiques
+func parsePracticalInfo(path string) (*domain.PracticalInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file practicalInfoFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("erreur parsing %s: %w", path, err)
+	}
+
+	info := &domain.PracticalInfo{}
+	for _, section := range file.Sections {
+		info.Sections = append(info.Sections, domain.Info{
+			ID:       section.ID,
+			Title:    section.Title,
+			Content:  section.Body,
+			Icon:     section.Icon,
+			Audience: domain.NormalizeGroups(section.Audience),
+		})
+	}
+
+	if err := info.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return info, nil
+}
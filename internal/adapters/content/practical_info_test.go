@@ -0,0 +1,129 @@
+package content
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestYAMLPracticalInfoSource(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "infos.fr.yaml")
+
+	writeFile(t, path, `
+sections:
+  - id: lieu
+    title: Le lieu
+    icon: "📍"
+    body: |
+      À **Cély**.
+  - title: Hébergement
+    audience: [Famille]
+    body: Hôtels
+`)
+
+	source := NewYAMLPracticalInfoSource(dir)
+
+	info, err := source.LoadPracticalInfo("fr")
+	if err != nil {
+		t.Fatalf("LoadPracticalInfo() error = %v", err)
+	}
+	if len(info.Sections) != 2 {
+		t.Fatalf("LoadPracticalInfo() returned %d sections, want 2", len(info.Sections))
+	}
+	if info.Sections[0].ID != "lieu" || info.Sections[0].Content != "À **Cély**.\n" {
+		t.Errorf("Section 0 = %+v", info.Sections[0])
+	}
+	if len(info.Sections[1].Audience) != 1 || info.Sections[1].Audience[0] != "famille" {
+		t.Errorf("Section 1 audience = %v, want [famille]", info.Sections[1].Audience)
+	}
+
+	// Un fichier modifié est relu
+	writeFile(t, path, "sections:\n  - title: FAQ\n")
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+	info, err = source.LoadPracticalInfo("fr")
+	if err != nil {
+		t.Fatalf("LoadPracticalInfo() after update error = %v", err)
+	}
+	if len(info.Sections) != 1 || info.Sections[0].Title != "FAQ" {
+		t.Errorf("LoadPracticalInfo() after update = %+v", info.Sections)
+	}
+
+	// Langue sans fichier
+	if _, err := source.LoadPracticalInfo("de"); !os.IsNotExist(err) {
+		t.Errorf("LoadPracticalInfo(de) error = %v, want not exist", err)
+	}
+
+	// Section sans titre
+	writeFile(t, filepath.Join(dir, "infos.en.yaml"), "sections:\n  - body: Texte\n")
+	if _, err := source.LoadPracticalInfo("en"); err == nil {
+		t.Error("LoadPracticalInfo() should reject a section without title")
+	}
+}
+
+func TestShippedContent(t *testing.T) {
+	source := NewYAMLPracticalInfoSource(filepath.Join("..", "..", "..", "web", "content"))
+
+	for _, lang := range []string{"fr", "de"} {
+		info, err := source.LoadPracticalInfo(lang)
+		if err != nil {
+			t.Errorf("LoadPracticalInfo(%s) error = %v", lang, err)
+			continue
+		}
+		if len(info.Sections) == 0 {
+			t.Errorf("LoadPracticalInfo(%s) returned no sections", lang)
+		}
+	}
+
+	accommodations := NewYAMLAccommodationSource(filepath.Join("..", "..", "..", "web", "content"))
+	for _, lang := range []string{"fr", "de"} {
+		if _, err := accommodations.LoadAccommodations(lang); err != nil {
+			t.Errorf("LoadAccommodations(%s) error = %v", lang, err)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestYAMLAccommodationSource(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "accommodations.fr.yaml"), `
+accommodations:
+  - id: londres
+    name: Hôtel de Londres
+    distance_km: 12
+    booking_code: MARIAGE
+    rooms_blocked: 8
+    block_deadline: 2026-05-15
+  - name: Gîte
+`)
+
+	accommodations, err := NewYAMLAccommodationSource(dir).LoadAccommodations("fr")
+	if err != nil {
+		t.Fatalf("LoadAccommodations() error = %v", err)
+	}
+	if len(accommodations) != 2 {
+		t.Fatalf("LoadAccommodations() returned %d accommodations, want 2", len(accommodations))
+	}
+	if accommodations[0].RoomsBlocked != 8 || accommodations[0].BlockDeadline.Format("2006-01-02") != "2026-05-15" {
+		t.Errorf("Accommodation 0 = %+v", accommodations[0])
+	}
+
+	writeFile(t, filepath.Join(dir, "accommodations.fr.yaml"), "accommodations:\n  - name: Gîte\n    block_deadline: 15/05/2026\n")
+	if _, err := NewYAMLAccommodationSource(dir).LoadAccommodations("fr"); err == nil {
+		t.Error("LoadAccommodations() should reject an invalid block_deadline")
+	}
+
+	if _, err := NewYAMLAccommodationSource(dir).LoadAccommodations("de"); !os.IsNotExist(err) {
+		t.Errorf("LoadAccommodations() without file error = %v, want not exist", err)
+	}
+}
@@ -0,0 +1,223 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"wedding-web/internal/application"
+	"wedding-web/internal/domain"
+)
+
+// carpoolErrorKeys associe les erreurs de publication à leur traduction
+var carpoolErrorKeys = errorKeys{
+	{domain.ErrInvalidName, "error.invalid_name"},
+	{domain.ErrInvalidContact, "error.invalid_contact"},
+	{domain.ErrInvalidSeats, "error.invalid_seats"},
+	{domain.ErrMessageTooLong, "error.message_too_long"},
+	{domain.ErrInvalidCarpool, "error.invalid_carpool"},
+	{application.ErrUnknownCarpoolLeg, "error.invalid_carpool"},
+	{application.ErrNotAttending, "error.not_attending"},
+}
+
+// carpoolEntryView associe une annonce à son trajet pour l'affichage
+type carpoolEntryView struct {
+	*domain.CarpoolEntry
+	Leg domain.CarpoolLeg
+}
+
+// CarpoolHandler affiche le tableau du covoiturage
+func (h *Handlers) CarpoolHandler(w ResponseWriter, r *Request) error {
+	return h.renderCarpool(w, r, h.currentInvitation(w, r), "", http.StatusOK)
+}
+
+// CarpoolPostHandler publie une offre ou une demande de places
+func (h *Handlers) CarpoolPostHandler(w ResponseWriter, r *Request) error {
+	if !h.parseGuestForm(w, r) {
+		return nil
+	}
+
+	invitation := h.currentInvitation(w, r)
+	if invitation == nil {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Invitation requise"))
+		return nil
+	}
+
+	seats, _ := strconv.Atoi(r.FormValue("seats"))
+	_, err := h.carpoolService.PostEntry(
+		invitation,
+		r.FormValue("kind"),
+		r.FormValue("leg"),
+		r.FormValue("name"),
+		r.FormValue("contact"),
+		seats,
+		r.FormValue("notes"),
+	)
+	if err != nil {
+		t := h.getTranslations(r, w)
+		return h.renderCarpool(w, r, invitation, t.T(carpoolErrorKeys.key(err)), http.StatusBadRequest)
+	}
+
+	http.Redirect(w, r.Request, "/carpool", http.StatusSeeOther)
+	return nil
+}
+
+// CarpoolWithdrawHandler retire une annonce du foyer invité
+func (h *Handlers) CarpoolWithdrawHandler(w ResponseWriter, r *Request) error {
+	if !h.parseGuestForm(w, r) {
+		return nil
+	}
+
+	invitation := h.currentInvitation(w, r)
+	if err := h.carpoolService.WithdrawEntry(invitation, r.FormValue("id")); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("Annonce introuvable"))
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/carpool", http.StatusSeeOther)
+	return nil
+}
+
+// renderCarpool affiche la page du covoiturage, réservée aux invités identifiés
+func (h *Handlers) renderCarpool(w ResponseWriter, r *Request, invitation *domain.Invitation, errorMsg string, status int) error {
+	h.reloadTemplates()
+
+	t := h.getTranslations(r, w)
+
+	data := map[string]interface{}{
+		"Title": t.T("nav.carpool"),
+		"Guest": invitation,
+		"Error": errorMsg,
+		"T":     t,
+		"Lang":  t.Lang(),
+		"Path":  r.URL.Path,
+	}
+
+	if invitation != nil {
+		board, err := h.carpoolService.Board(invitation)
+		if err != nil {
+			return err
+		}
+
+		entries, err := h.carpoolService.EntriesOf(invitation)
+		if err != nil {
+			return err
+		}
+
+		attending, err := h.carpoolService.CanPost(invitation)
+		if err != nil {
+			return err
+		}
+
+		// Trajets et tableau dans la langue de la page
+		legs := h.carpoolService.Legs(invitation)
+		for i := range legs {
+			legs[i] = legs[i].In(t.Lang())
+		}
+		for i := range board {
+			board[i].Leg = board[i].Leg.In(t.Lang())
+		}
+		ownEntries := make([]carpoolEntryView, 0, len(entries))
+		for _, entry := range entries {
+			view := carpoolEntryView{CarpoolEntry: entry}
+			for _, leg := range legs {
+				if leg.ID == entry.LegID {
+					view.Leg = leg
+				}
+			}
+			ownEntries = append(ownEntries, view)
+		}
+
+		sessionID := getOrCreateSession(w, r.Request)
+		csrfToken, err := h.csrfManager.GenerateToken(sessionID)
+		if err != nil {
+			return err
+		}
+
+		data["Board"] = board
+		data["Legs"] = legs
+		data["Attending"] = attending
+		data["MyEntries"] = ownEntries
+		data["CSRFToken"] = csrfToken
+	}
+
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+	}
+	return h.templates.ExecuteTemplate(w, "carpool.html", data)
+}
+
+// AdminCarpoolHandler affiche la synthèse du covoiturage pour organiser les navettes
+func (h *Handlers) AdminCarpoolHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	h.reloadTemplates()
+
+	board, err := h.carpoolService.Overview()
+	if err != nil {
+		return err
+	}
+
+	shuttleSeats := 0
+	for _, leg := range board {
+		shuttleSeats += leg.ShuttleSeats()
+	}
+
+	data := map[string]interface{}{
+		"Title":        "Administration - Covoiturage",
+		"Board":        board,
+		"ShuttleSeats": shuttleSeats,
+	}
+
+	return h.templates.ExecuteTemplate(w, "admin_carpool.html", data)
+}
+
+// AdminCarpoolDeleteHandler supprime une annonce de covoiturage
+func (h *Handlers) AdminCarpoolDeleteHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	// Récupérer l'ID depuis l'URL
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("ID manquant"))
+		return nil
+	}
+
+	if err := h.carpoolService.DeleteEntry(id); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Erreur lors de la suppression"))
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/carpool", http.StatusSeeOther)
+	return nil
+}
+
+// AdminCarpoolExportHandler exporte le covoiturage en Excel
+func (h *Handlers) AdminCarpoolExportHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	board, err := h.carpoolService.Overview()
+	if err != nil {
+		return err
+	}
+
+	file, err := h.exportService.ExportCarpoolToExcel(board)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", h.exportService.GetCarpoolFileName()))
+
+	return file.Write(w)
+}
@@ -0,0 +1,20 @@
+package http
+
+import "errors"
+
+// errorKeys associe des erreurs métier à leur clé de traduction
+type errorKeys []struct {
+	err error
+	key string
+}
+
+// key retourne la clé de traduction de la première erreur reconnue par
+// errors.Is, ou le message d'erreur générique
+func (keys errorKeys) key(err error) string {
+	for _, mapping := range keys {
+		if errors.Is(err, mapping.err) {
+			return mapping.key
+		}
+	}
+	return "error.desc"
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"net/http"
+	"wedding-web/internal/domain"
+)
+
+// guestbookErrorKeys associe les erreurs du livre d'or à leur traduction
+var guestbookErrorKeys = errorKeys{
+	{domain.ErrInvalidName, "error.invalid_name"},
+	{domain.ErrEmptyMessage, "error.empty_message"},
+	{domain.ErrMessageTooLong, "error.message_too_long"},
+	{domain.ErrLinkNotAllowed, "error.link_not_allowed"},
+	{domain.ErrForbiddenContent, "error.forbidden_content"},
+}
+
+// GuestbookHandler affiche les messages publiés et le formulaire du livre d'or
+func (h *Handlers) GuestbookHandler(w ResponseWriter, r *Request) error {
+	return h.renderGuestbook(w, r, "", http.StatusOK)
+}
+
+// GuestbookPostHandler enregistre un message, publié après modération
+func (h *Handlers) GuestbookPostHandler(w ResponseWriter, r *Request) error {
+	if !h.parseGuestForm(w, r) {
+		return nil
+	}
+
+	_, err := h.guestbookService.PostEntry(
+		h.currentInvitation(w, r),
+		r.FormValue("name"),
+		r.FormValue("message"),
+		getClientIP(r.Request),
+	)
+	if err != nil {
+		t := h.getTranslations(r, w)
+		return h.renderGuestbook(w, r, t.T(guestbookErrorKeys.key(err)), http.StatusBadRequest)
+	}
+
+	http.Redirect(w, r.Request, "/guestbook?sent=1", http.StatusSeeOther)
+	return nil
+}
+
+// renderGuestbook affiche la page du livre d'or
+func (h *Handlers) renderGuestbook(w ResponseWriter, r *Request, errorMsg string, status int) error {
+	h.reloadTemplates()
+
+	t := h.getTranslations(r, w)
+	invitation := h.currentInvitation(w, r)
+
+	entries, err := h.guestbookService.Approved()
+	if err != nil {
+		return err
+	}
+
+	sessionID := getOrCreateSession(w, r.Request)
+	csrfToken, err := h.csrfManager.GenerateToken(sessionID)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"Title":     t.T("nav.guestbook"),
+		"Guest":     invitation,
+		"Entries":   entries,
+		"Sent":      r.URL.Query().Get("sent") != "",
+		"Error":     errorMsg,
+		"CSRFToken": csrfToken,
+		"T":         t,
+		"Lang":      t.Lang(),
+		"Path":      r.URL.Path,
+	}
+
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+	}
+	return h.templates.ExecuteTemplate(w, "guestbook.html", data)
+}
+
+// AdminGuestbookHandler affiche la file de modération et les messages publiés
+func (h *Handlers) AdminGuestbookHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	h.reloadTemplates()
+
+	pending, err := h.guestbookService.Pending()
+	if err != nil {
+		return err
+	}
+
+	approved, err := h.guestbookService.Approved()
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"Title":    "Administration - Livre d'or",
+		"Pending":  pending,
+		"Approved": approved,
+	}
+
+	return h.templates.ExecuteTemplate(w, "admin_guestbook.html", data)
+}
+
+// AdminGuestbookApproveHandler publie un message de la file de modération
+func (h *Handlers) AdminGuestbookApproveHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	// Récupérer l'ID depuis l'URL
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("ID manquant"))
+		return nil
+	}
+
+	if err := h.guestbookService.Approve(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("Message introuvable"))
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/guestbook", http.StatusSeeOther)
+	return nil
+}
+
+// AdminGuestbookDeleteHandler refuse ou retire un message du livre d'or
+func (h *Handlers) AdminGuestbookDeleteHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	// Récupérer l'ID depuis l'URL
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("ID manquant"))
+		return nil
+	}
+
+	if err := h.guestbookService.DeleteEntry(id); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Erreur lors de la suppression"))
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/guestbook", http.StatusSeeOther)
+	return nil
+}
@@ -1,30 +1,89 @@
 package http
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"log"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 	"wedding-web/internal/application"
+	"wedding-web/internal/domain"
 	"wedding-web/internal/i18n"
+	"wedding-web/internal/markdown"
 )
 
+// rsvpErrorKeys associe les erreurs de réponse RSVP à leur traduction
+var rsvpErrorKeys = errorKeys{
+	{domain.ErrInvalidName, "error.invalid_name"},
+	{domain.ErrInvalidGuests, "error.invalid_guests"},
+	{domain.ErrMessageTooLong, "error.message_too_long"},
+	{domain.ErrAllergiesTooLong, "error.allergies_too_long"},
+	{domain.ErrInvalidNights, "error.invalid_nights"},
+	{domain.ErrInvalidSong, "error.invalid_song"},
+	{domain.ErrInvalidSongLink, "error.invalid_song_link"},
+	{domain.ErrInvalidEmail, "error.invalid_email"},
+	{application.ErrRSVPNotFound, "error.rsvp_not_found"},
+}
+
 // Handlers contient tous les handlers HTTP
 type Handlers struct {
-	rsvpService     *application.RSVPService
-	planningService *application.PlanningService
-	infoService     *application.InfoService
-	calendarService *application.CalendarService
-	exportService   *application.ExportService
-	csrfManager     *CSRFManager
-	templates       *template.Template
-	templatesDir    string // Pour recharger les templates en dev
-	isDev           bool   // Mode développement
-	adminUsername   string // Nom d'utilisateur admin
-	adminPassword   string // Mot de passe admin
+	rsvpService          *application.RSVPService
+	planningService      *application.PlanningService
+	infoService          *application.InfoService
+	calendarService      *application.CalendarService
+	invitationService    *application.InvitationService
+	accommodationService *application.AccommodationService
+	carpoolService       *application.CarpoolService
+	songService          *application.SongService
+	guestbookService     *application.GuestbookService
+	registryService      *application.RegistryService
+	photoService         *application.PhotoService
+	seatingService       *application.SeatingService
+	thankYouService      *application.ThankYouService
+	notificationService  *application.NotificationService     // nil : notifications désactivées
+	reminderService      *application.ReminderCampaignService // nil : relances désactivées
+	exportService        *application.ExportService
+	printService         *application.PrintService
+	venueService         *application.VenueService
+	csrfManager          *CSRFManager
+	templates            *template.Template
+	templatesDir         string // Pour recharger les templates en dev
+	isDev                bool   // Mode développement
+	baseURL              string // URL publique du site (liens webcal)
+	adminUsername        string // Nom d'utilisateur admin
+	adminPassword        string // Mot de passe admin
+}
+
+// pageTemplates liste les templates de pages chargés au démarrage
+var pageTemplates = []string{
+	"home.html",
+	"planning.html",
+	"infos.html",
+	"rsvp.html",
+	"confirmation.html",
+	"error.html",
+	"admin.html",
+	"admin_invitations.html",
+	"carpool.html",
+	"admin_carpool.html",
+	"songs.html",
+	"admin_songs.html",
+	"guestbook.html",
+	"admin_guestbook.html",
+	"registry.html",
+	"admin_registry.html",
+	"photos.html",
+	"admin_photos.html",
+	"admin_seating.html",
+	"admin_seating_print.html",
+	"admin_thanks.html",
 }
 
 // NewHandlers crée une nouvelle instance des handlers
@@ -33,56 +92,112 @@ func NewHandlers(
 	planningService *application.PlanningService,
 	infoService *application.InfoService,
 	calendarService *application.CalendarService,
+	invitationService *application.InvitationService,
+	accommodationService *application.AccommodationService,
+	carpoolService *application.CarpoolService,
+	songService *application.SongService,
+	guestbookService *application.GuestbookService,
+	registryService *application.RegistryService,
+	photoService *application.PhotoService,
+	seatingService *application.SeatingService,
+	thankYouService *application.ThankYouService,
+	notificationService *application.NotificationService,
+	reminderService *application.ReminderCampaignService,
 	csrfManager *CSRFManager,
 	templatesDir string,
 	isDev bool,
+	baseURL string,
 	adminUsername string,
 	adminPassword string,
 ) (*Handlers, error) {
+	tmpl, err := parseTemplates(templatesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Créer le service d'export
+	exportService := application.NewExportService(rsvpService, seatingService)
+	printService := application.NewPrintService(seatingService)
+	venueService := application.NewVenueService()
+
+	return &Handlers{
+		rsvpService:          rsvpService,
+		planningService:      planningService,
+		infoService:          infoService,
+		calendarService:      calendarService,
+		invitationService:    invitationService,
+		accommodationService: accommodationService,
+		carpoolService:       carpoolService,
+		songService:          songService,
+		guestbookService:     guestbookService,
+		registryService:      registryService,
+		photoService:         photoService,
+		seatingService:       seatingService,
+		thankYouService:      thankYouService,
+		notificationService:  notificationService,
+		reminderService:      reminderService,
+		exportService:        exportService,
+		printService:         printService,
+		venueService:         venueService,
+		csrfManager:          csrfManager,
+		templates:            tmpl,
+		templatesDir:         templatesDir,
+		isDev:                isDev,
+		baseURL:              strings.TrimSuffix(baseURL, "/"),
+		adminUsername:        adminUsername,
+		adminPassword:        adminPassword,
+	}, nil
+}
+
+// parseTemplates charge les partials puis les templates de pages
+func parseTemplates(templatesDir string) (*template.Template, error) {
 	// Créer les fonctions template personnalisées
 	funcMap := template.FuncMap{
-		"T": func(t *i18n.Translations, key string) string {
-			return t.T(key)
+		"T": func(t *i18n.Translations, key string, args ...interface{}) string {
+			return t.T(key, args...)
+		},
+		"markdown": markdown.Render,
+		"locales":  i18n.Locales,
+		"date": func(lang string, date time.Time) string {
+			return i18n.NewTranslations(i18n.Lang(lang)).FormatDate(date)
+		},
+		"longDate": func(lang string, date time.Time) string {
+			return i18n.NewTranslations(i18n.Lang(lang)).FormatLongDate(date)
+		},
+		"clock": func(lang string, date time.Time) string {
+			return i18n.NewTranslations(i18n.Lang(lang)).FormatTime(date)
+		},
+		"dateTime": func(lang string, date time.Time) string {
+			return i18n.NewTranslations(i18n.Lang(lang)).FormatDateTime(date)
+		},
+		"langURL": func(lang i18n.Lang, path interface{}) string {
+			p, _ := path.(string) // Pages sans chemin (erreurs) : page courante
+			return i18n.URL(lang, p)
+		},
+		"upper": strings.ToUpper,
+		"langName": func(code string) string {
+			for _, locale := range i18n.Locales() {
+				if string(locale.Code) == code {
+					return locale.Name
+				}
+			}
+			return strings.ToUpper(code)
 		},
 	}
 
-	// Charger les templates avec les partials
+	// Charger les partials d'abord
 	tmpl := template.New("").Funcs(funcMap)
 	tmpl, err := tmpl.ParseGlob(filepath.Join(templatesDir, "partials", "*.html"))
 	if err != nil {
 		return nil, err
 	}
 
-	// Charger les templates de pages
-	tmpl, err = tmpl.ParseFiles(
-		filepath.Join(templatesDir, "home.html"),
-		filepath.Join(templatesDir, "planning.html"),
-		filepath.Join(templatesDir, "infos.html"),
-		filepath.Join(templatesDir, "rsvp.html"),
-		filepath.Join(templatesDir, "confirmation.html"),
-		filepath.Join(templatesDir, "error.html"),
-		filepath.Join(templatesDir, "admin.html"),
-	)
-	if err != nil {
-		return nil, err
+	// Puis charger les templates de pages
+	files := make([]string, len(pageTemplates))
+	for i, name := range pageTemplates {
+		files[i] = filepath.Join(templatesDir, name)
 	}
-
-	// Créer le service d'export
-	exportService := application.NewExportService(rsvpService)
-
-	return &Handlers{
-		rsvpService:     rsvpService,
-		planningService: planningService,
-		infoService:     infoService,
-		calendarService: calendarService,
-		exportService:   exportService,
-		csrfManager:     csrfManager,
-		templates:       tmpl,
-		templatesDir:    templatesDir,
-		isDev:           isDev,
-		adminUsername:   adminUsername,
-		adminPassword:   adminPassword,
-	}, nil
+	return tmpl.ParseFiles(files...)
 }
 
 // reloadTemplates recharge les templates en mode dev
@@ -91,41 +206,41 @@ func (h *Handlers) reloadTemplates() {
 		return
 	}
 
-	// Créer les fonctions template personnalisées
-	funcMap := template.FuncMap{
-		"T": func(t *i18n.Translations, key string) string {
-			return t.T(key)
-		},
+	tmpl, err := parseTemplates(h.templatesDir)
+	if err == nil {
+		h.templates = tmpl
 	}
+}
 
-	// Charger les partials d'abord
-	tmpl := template.New("").Funcs(funcMap)
-	tmpl, err := tmpl.ParseGlob(filepath.Join(h.templatesDir, "partials", "*.html"))
-	if err != nil {
-		return
+// requireAdmin vérifie l'authentification admin.
+// Retourne false si la réponse a déjà été envoyée (admin désactivé ou non authentifié).
+func (h *Handlers) requireAdmin(w ResponseWriter, r *Request) bool {
+	// Vérifier si l'admin est configuré
+	if h.adminPassword == "" || h.adminUsername == "" {
+		w.WriteHeader(http.StatusNotFound)
+		return false
 	}
 
-	// Puis charger les templates de pages
-	tmpl, err = tmpl.ParseFiles(
-		filepath.Join(h.templatesDir, "home.html"),
-		filepath.Join(h.templatesDir, "planning.html"),
-		filepath.Join(h.templatesDir, "infos.html"),
-		filepath.Join(h.templatesDir, "rsvp.html"),
-		filepath.Join(h.templatesDir, "confirmation.html"),
-		filepath.Join(h.templatesDir, "error.html"),
-		filepath.Join(h.templatesDir, "admin.html"),
-	)
-	if err == nil {
-		h.templates = tmpl
+	// Vérifier l'authentification
+	username, password, ok := r.BasicAuth()
+	if !ok || username != h.adminUsername || password != h.adminPassword {
+		w.Header().Set("WWW-Authenticate", `Basic realm="Administration - RSVP Mariage"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("Authentification requise"))
+		return false
 	}
+
+	return true
 }
 
 // getTranslations récupère les traductions depuis la requête et définit le cookie
 func (h *Handlers) getTranslations(r *Request, w ResponseWriter) *i18n.Translations {
-	lang := i18n.GetLangFromRequest(r.Request)
+	lang, chosen := i18n.RequestLang(r.Request)
 
-	// Toujours définir le cookie pour persister la languevps chez 
-	i18n.SetLangCookie(w, lang)
+	// Persister la langue choisie ; une langue négociée suit le navigateur
+	if chosen {
+		i18n.SetLangCookie(w, lang)
+	}
 
 	return i18n.NewTranslations(lang)
 }
@@ -138,8 +253,10 @@ func (h *Handlers) HomeHandler(w ResponseWriter, r *Request) error {
 
 	data := map[string]interface{}{
 		"Title": "A & G",
+		"Guest": h.currentInvitation(w, r),
 		"T":     t,
 		"Lang":  t.Lang(),
+		"Path":  r.URL.Path,
 	}
 
 	return h.templates.ExecuteTemplate(w, "home.html", data)
@@ -150,35 +267,121 @@ func (h *Handlers) PlanningHandler(w ResponseWriter, r *Request) error {
 	h.reloadTemplates()
 
 	t := h.getTranslations(r, w)
-	planning := h.planningService.GetPlanning()
+	invitation := h.currentInvitation(w, r)
+	planning := h.planningService.GetPlanningFor(invitation).In(t.Lang())
+
+	// Le flux d'abonnement est personnel si l'invité est identifié
+	code := ""
+	if invitation != nil {
+		code = invitation.Code
+	}
 
 	data := map[string]interface{}{
 		"Title":    t.T("nav.planning"),
 		"Planning": planning,
+		"Events":   h.planningEventViews(planning),
+		"FeedURL":  h.calendarFeedURL(code, t.Lang()),
+		"Guest":    invitation,
 		"T":        t,
 		"Lang":     t.Lang(),
+		"Path":     r.URL.Path,
 	}
 
 	return h.templates.ExecuteTemplate(w, "planning.html", data)
 }
 
+// planningEventView enrichit un événement de ses liens d'export individuels
+type planningEventView struct {
+	domain.PlanningEvent
+	ICSURL        string
+	GoogleURL     string
+	OutlookURL    string
+	DirectionsURL string
+	NewDay        bool // Premier événement d'une journée : la date est affichée
+}
+
+// planningEventViews prépare les événements du planning pour l'affichage.
+// Les liens d'export ne sont générés que pour les événements avec horaire.
+func (h *Handlers) planningEventViews(planning *domain.Planning) []planningEventView {
+	views := make([]planningEventView, 0, len(planning.Events))
+	day := ""
+	for _, event := range planning.Events {
+		view := planningEventView{
+			PlanningEvent: event,
+			DirectionsURL: h.venueService.GoogleMapsDirectionsURL(event.Venue),
+		}
+		if !event.StartTime.IsZero() && event.StartTime.Format("2006-01-02") != day {
+			day = event.StartTime.Format("2006-01-02")
+			view.NewDay = true
+		}
+		if !event.HideTime && !event.StartTime.IsZero() && event.ID != "" {
+			view.ICSURL = "/calendar/event.ics?id=" + url.QueryEscape(event.ID)
+			view.GoogleURL = h.calendarService.GoogleCalendarURL(event)
+			view.OutlookURL = h.calendarService.OutlookCalendarURL(event)
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
 // InfosHandler affiche les infos pratiques
 func (h *Handlers) InfosHandler(w ResponseWriter, r *Request) error {
 	h.reloadTemplates()
 
 	t := h.getTranslations(r, w)
-	info := h.infoService.GetPracticalInfo()
+	invitation := h.currentInvitation(w, r)
+	info, err := h.infoService.GetPracticalInfoFor(invitation, i18n.Lang(t.Lang()))
+	if err != nil {
+		return err
+	}
+	accommodations, err := h.accommodationService.ListAccommodations(i18n.Lang(t.Lang()))
+	if err != nil {
+		return err
+	}
 
 	data := map[string]interface{}{
-		"Title": t.T("nav.info"),
-		"Info":  info,
-		"T":     t,
-		"Lang":  t.Lang(),
+		"Title":                t.T("nav.info"),
+		"Info":                 info,
+		"Venues":               h.venueViews(info.Venues),
+		"Map":                  h.venueService.StaticMap(info.Venues),
+		"Accommodations":       accommodations,
+		"AccommodationsAnchor": domain.AccommodationsAnchor,
+		"Guest":                invitation,
+		"T":                    t,
+		"Lang":                 t.Lang(),
+		"Path":                 r.URL.Path,
 	}
 
 	return h.templates.ExecuteTemplate(w, "infos.html", data)
 }
 
+// venueView enrichit un lieu de son numéro sur le plan et de ses itinéraires
+type venueView struct {
+	*domain.Venue
+	Number        int // Numéro du repère sur le plan (0: non géolocalisé)
+	OSMURL        string
+	GoogleMapsURL string
+}
+
+// venueViews prépare les lieux pour l'affichage, numérotés comme sur le plan
+func (h *Handlers) venueViews(venues []*domain.Venue) []venueView {
+	views := make([]venueView, 0, len(venues))
+	number := 0
+	for _, venue := range venues {
+		view := venueView{
+			Venue:         venue,
+			OSMURL:        h.venueService.OpenStreetMapDirectionsURL(venue),
+			GoogleMapsURL: h.venueService.GoogleMapsDirectionsURL(venue),
+		}
+		if venue.HasCoordinates() {
+			number++
+			view.Number = number
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
 // RSVPGetHandler affiche le formulaire RSVP
 func (h *Handlers) RSVPGetHandler(w ResponseWriter, r *Request) error {
 	h.reloadTemplates()
@@ -195,10 +398,41 @@ func (h *Handlers) RSVPGetHandler(w ResponseWriter, r *Request) error {
 	}
 
 	data := map[string]interface{}{
-		"Title":     t.T("nav.rsvp"),
-		"CSRFToken": csrfToken,
-		"T":         t,
-		"Lang":      t.Lang(),
+		"Title":          t.T("nav.rsvp"),
+		"CSRFToken":      csrfToken,
+		"Nights":         h.accommodationService.Nights(),
+		"Counts":         []int{0, 1, 2, 3, 4, 5},
+		"AdultsCount":    1,
+		"ChildrenCount":  0,
+		"Answer":         &domain.RSVP{}, // Réponse reprise par le lien de modification
+		"SelectedNights": map[string]bool{},
+		"ConfirmByEmail": h.notificationService != nil && h.notificationService.ConfirmsGuests(),
+		"T":              t,
+		"Lang":           t.Lang(),
+		"Path":           r.URL.Path,
+	}
+
+	// Lien de modification : le formulaire reprend la réponse enregistrée
+	if editToken := r.URL.Query().Get("edit"); editToken != "" {
+		rsvp, err := h.rsvpService.GetRSVPByEditToken(editToken)
+		if err != nil {
+			data["Title"] = t.T("error.title")
+			data["Error"] = t.T(rsvpErrorKeys.key(err))
+			w.WriteHeader(http.StatusNotFound)
+			return h.templates.ExecuteTemplate(w, "error.html", data)
+		}
+
+		selectedNights := make(map[string]bool, len(rsvp.AccommodationNights))
+		for _, night := range rsvp.AccommodationNights {
+			selectedNights[night] = true
+		}
+		data["Answer"] = rsvp
+		data["EditToken"] = editToken
+		data["SelectedNights"] = selectedNights
+		if rsvp.WillAttend {
+			data["AdultsCount"] = rsvp.AdultsCount
+			data["ChildrenCount"] = rsvp.ChildrenCount
+		}
 	}
 
 	return h.templates.ExecuteTemplate(w, "rsvp.html", data)
@@ -206,41 +440,7 @@ func (h *Handlers) RSVPGetHandler(w ResponseWriter, r *Request) error {
 
 // RSVPPostHandler traite la soumission du formulaire RSVP
 func (h *Handlers) RSVPPostHandler(w ResponseWriter, r *Request) error {
-	// Vérifier le Content-Type
-	if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("Content-Type invalide"))
-		return nil
-	}
-
-	// Parser le formulaire
-	if err := r.ParseForm(); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("Formulaire invalide"))
-		return nil
-	}
-
-	// Vérifier le token CSRF
-	cookie, err := r.Cookie("session_id")
-	if err != nil {
-		w.WriteHeader(http.StatusForbidden)
-		w.Write([]byte("Session invalide"))
-		return nil
-	}
-
-	csrfToken := r.FormValue("csrf_token")
-	if !h.csrfManager.ValidateToken(cookie.Value, csrfToken) {
-		w.WriteHeader(http.StatusForbidden)
-		w.Write([]byte("Token CSRF invalide"))
-		return nil
-	}
-
-	// Vérifier le honeypot (champ caché anti-spam)
-	honeypot := r.FormValue("website")
-	if honeypot != "" {
-		// C'est probablement un bot
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("Requête invalide"))
+	if !h.parseGuestForm(w, r) {
 		return nil
 	}
 
@@ -269,30 +469,54 @@ func (h *Handlers) RSVPPostHandler(w ResponseWriter, r *Request) error {
 		message = strings.TrimSpace(r.FormValue("absence_message"))
 	}
 
-	// Récupérer l'IP
+	// Email facultatif, pour la confirmation envoyée à l'invité
+	email := strings.TrimSpace(r.FormValue("presence_email"))
+	if email == "" {
+		email = strings.TrimSpace(r.FormValue("absence_email"))
+	}
+
+	// Nuits d'hébergement, seulement si le besoin est coché
+	var accommodationNights []string
+	if r.FormValue("needs_accommodation") == "yes" {
+		accommodationNights = r.Form["accommodation_nights"]
+	}
+
+	// Suggestion musicale facultative, vérifiée avant d'enregistrer la réponse
+	songTitle := strings.TrimSpace(r.FormValue("song_title"))
+	songArtist := strings.TrimSpace(r.FormValue("song_artist"))
+	songLink := strings.TrimSpace(r.FormValue("song_link"))
+	suggestsSong := willAttend && (songTitle != "" || songArtist != "" || songLink != "")
+
+	// Récupérer l'IP et l'invitation du foyer
 	ip := getClientIP(r.Request)
+	invitation := h.currentInvitation(w, r)
 
-	// Soumettre le RSVP
-	rsvp, err := h.rsvpService.SubmitRSVP(firstName, lastName, willAttend, adultsCount, childrenCount, allergies, message, ip)
+	lang := i18n.GetLangFromRequest(r.Request)
+	editToken := r.FormValue("edit")
+
+	// Soumettre le RSVP, ou modifier celui du lien de modification
+	var rsvp *domain.RSVP
+	var err error
+	if suggestsSong {
+		_, err = domain.NewSongSuggestion("", songTitle, songArtist, songLink)
+	}
+	if err == nil && editToken != "" {
+		rsvp, err = h.rsvpService.UpdateRSVP(editToken, firstName, lastName, willAttend, adultsCount, childrenCount, allergies, message, email, accommodationNights, ip, lang)
+		if err == nil && rsvp.InvitationID != "" {
+			// L'invitation de la réponse, le lien n'ayant pas forcément le code
+			if found, findErr := h.invitationService.GetInvitation(rsvp.InvitationID); findErr == nil {
+				invitation = found
+			}
+		}
+	} else if err == nil {
+		rsvp, err = h.rsvpService.SubmitRSVP(invitation, firstName, lastName, willAttend, adultsCount, childrenCount, allergies, message, email, accommodationNights, ip, lang)
+	}
 	if err != nil {
 		t := h.getTranslations(r, w)
 
-		// Traduire le message d'erreur
-		errorMsg := err.Error()
-		switch errorMsg {
-		case "nom invalide":
-			errorMsg = t.T("error.invalid_name")
-		case "nombre d'invités invalide":
-			errorMsg = t.T("error.invalid_guests")
-		case "message trop long":
-			errorMsg = t.T("error.message_too_long")
-		case "allergies trop longues":
-			errorMsg = t.T("error.allergies_too_long")
-		}
-
 		data := map[string]interface{}{
 			"Title": t.T("error.title"),
-			"Error": errorMsg,
+			"Error": t.T(rsvpErrorKeys.key(err)),
 			"T":     t,
 			"Lang":  t.Lang(),
 		}
@@ -300,6 +524,23 @@ func (h *Handlers) RSVPPostHandler(w ResponseWriter, r *Request) error {
 		return h.templates.ExecuteTemplate(w, "error.html", data)
 	}
 
+	// Prévenir les mariés, sans faire échouer la réponse
+	if h.notificationService != nil {
+		if err := h.notificationService.RSVPSubmitted(rsvp, invitation); err != nil {
+			log.Printf("Erreur lors de la notification de la réponse: %v", err)
+		}
+		planning := h.planningService.GetPlanningFor(invitation)
+		if err := h.notificationService.ConfirmRSVP(rsvp, planning, h.rsvpEditURL(rsvp)); err != nil {
+			log.Printf("Erreur lors de la confirmation de la réponse à l'invité: %v", err)
+		}
+	}
+
+	if suggestsSong {
+		if _, err := h.songService.SuggestSong(invitation, rsvp.ID, rsvp.FullName(), songTitle, songArtist, songLink); err != nil {
+			log.Printf("Erreur lors de l'enregistrement de la suggestion musicale: %v", err)
+		}
+	}
+
 	// Redirection vers la page de confirmation
 	t := h.getTranslations(r, w)
 	data := map[string]interface{}{
@@ -309,17 +550,92 @@ func (h *Handlers) RSVPPostHandler(w ResponseWriter, r *Request) error {
 		"Total":     rsvp.TotalGuests(),
 		"T":         t,
 		"Lang":      t.Lang(),
+		"Path":      r.URL.Path,
 	}
 
 	return h.templates.ExecuteTemplate(w, "confirmation.html", data)
 }
 
+// rsvpEditURL retourne le lien de modification d'une réponse, dans la
+// langue de l'invité
+func (h *Handlers) rsvpEditURL(rsvp *domain.RSVP) string {
+	link := h.baseURL + i18n.URL(i18n.Lang(rsvp.Lang), "/rsvp")
+	separator := "?"
+	if strings.Contains(link, "?") {
+		separator = "&"
+	}
+	return link + separator + "edit=" + url.QueryEscape(rsvp.EditToken)
+}
+
+// parseGuestForm applique les protections communes aux formulaires publics
+// (Content-Type, parsing, token CSRF, honeypot anti-spam).
+// Retourne false si la réponse d'erreur a déjà été écrite.
+func (h *Handlers) parseGuestForm(w ResponseWriter, r *Request) bool {
+	// Vérifier le Content-Type
+	if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Content-Type invalide"))
+		return false
+	}
+
+	// Parser le formulaire
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Formulaire invalide"))
+		return false
+	}
+
+	return h.checkGuestForm(w, r)
+}
+
+// checkGuestForm vérifie le token CSRF et le honeypot d'un formulaire invité
+// déjà parsé (urlencoded ou multipart).
+// Retourne false si la réponse d'erreur a déjà été envoyée.
+func (h *Handlers) checkGuestForm(w ResponseWriter, r *Request) bool {
+	// Vérifier le token CSRF
+	if !h.verifyCSRF(w, r) {
+		return false
+	}
+
+	// Vérifier le honeypot (champ caché anti-spam)
+	honeypot := r.FormValue("website")
+	if honeypot != "" {
+		// C'est probablement un bot
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Requête invalide"))
+		return false
+	}
+
+	return true
+}
+
+// verifyCSRF vérifie le token CSRF d'un formulaire déjà parsé.
+// Retourne false si la réponse d'erreur a déjà été envoyée.
+func (h *Handlers) verifyCSRF(w ResponseWriter, r *Request) bool {
+	cookie, err := r.Cookie("session_id")
+	if err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Session invalide"))
+		return false
+	}
+
+	csrfToken := r.FormValue("csrf_token")
+	if !h.csrfManager.ValidateToken(cookie.Value, csrfToken) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Token CSRF invalide"))
+		return false
+	}
+
+	return true
+}
+
 // CalendarHandler génère et retourne un fichier .ics
 func (h *Handlers) CalendarHandler(w ResponseWriter, r *Request) error {
-	planning := h.planningService.GetPlanning()
+	planning := h.planningService.GetPlanningFor(h.currentInvitation(w, r))
 
 	// Générer le fichier ICS
-	icsData, err := h.calendarService.GenerateICS(planning)
+	lang := string(i18n.GetLangFromRequest(r.Request))
+	icsData, err := h.calendarService.GenerateICS(planning, lang)
 	if err != nil {
 		return err
 	}
@@ -333,6 +649,86 @@ func (h *Handlers) CalendarHandler(w ResponseWriter, r *Request) error {
 	return nil
 }
 
+// EventCalendarHandler retourne un fichier .ics pour un seul événement du planning
+func (h *Handlers) EventCalendarHandler(w ResponseWriter, r *Request) error {
+	planning := h.planningService.GetPlanningFor(h.currentInvitation(w, r))
+	event, ok := planning.FindEvent(r.URL.Query().Get("id"))
+	if !ok {
+		return h.NotFoundHandler(w, r)
+	}
+
+	lang := string(i18n.GetLangFromRequest(r.Request))
+	icsData, err := h.calendarService.GenerateEventICS(planning, event, lang)
+	if err != nil {
+		return h.NotFoundHandler(w, r)
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.ics", event.ID))
+	w.Header().Set("Cache-Control", "no-cache")
+
+	w.Write(icsData)
+	return nil
+}
+
+// CalendarFeedHandler sert le flux d'abonnement webcal du planning.
+// Avec ?token=<code>, le flux est personnalisé pour l'invitation correspondante.
+func (h *Handlers) CalendarFeedHandler(w ResponseWriter, r *Request) error {
+	var invitation *domain.Invitation
+	if token := r.URL.Query().Get("token"); token != "" {
+		found, err := h.invitationService.GetInvitationByCode(token)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("Calendrier introuvable"))
+			return nil
+		}
+		invitation = found
+	}
+
+	// Les applications de calendrier n'envoient pas de cookie : la langue
+	// des rappels est portée par le paramètre ?lang de l'URL d'abonnement
+	lang := string(i18n.GetLangFromRequest(r.Request))
+	planning := h.planningService.GetPlanning()
+	icsData, err := h.calendarService.GenerateFeed(planning, invitation, lang)
+	if err != nil {
+		return err
+	}
+
+	// ETag et Last-Modified permettent aux clients abonnés de ne
+	// retélécharger le flux que s'il a changé (réponse 304 sinon)
+	sum := sha256.Sum256(icsData)
+	w.Header().Set("ETag", fmt.Sprintf(`"%x"`, sum[:16]))
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", "inline; filename=mariage-2026.ics")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	http.ServeContent(w, r.Request, "mariage-2026.ics", h.calendarService.LastModified(planning), bytes.NewReader(icsData))
+	return nil
+}
+
+// calendarFeedURL retourne l'URL webcal:// du flux, personnalisée si un code est fourni.
+// Le type template.URL évite que html/template neutralise le schéma webcal.
+func (h *Handlers) calendarFeedURL(code, lang string) template.URL {
+	params := url.Values{}
+	if code != "" {
+		params.Set("token", code)
+	}
+	if lang != "" {
+		params.Set("lang", lang)
+	}
+
+	feedURL := h.baseURL + "/calendar/feed.ics"
+	if len(params) > 0 {
+		feedURL += "?" + params.Encode()
+	}
+
+	// webcal:// déclenche l'abonnement dans les applications de calendrier
+	if i := strings.Index(feedURL, "://"); i >= 0 {
+		feedURL = feedURL[i+len("://"):]
+	}
+	return template.URL("webcal://" + feedURL) // #nosec G203 -- URL construite à partir de la configuration
+}
+
 // HealthHandler retourne le statut de santé du service
 func (h *Handlers) HealthHandler(w ResponseWriter, r *Request) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -349,7 +745,7 @@ func (h *Handlers) NotFoundHandler(w ResponseWriter, r *Request) error {
 
 	data := map[string]interface{}{
 		"Title":   t.T("error.title"),
-		"Message": "Page non trouvée",
+		"Message": t.T("error.not_found"),
 		"T":       t,
 		"Lang":    t.Lang(),
 	}
@@ -359,18 +755,7 @@ func (h *Handlers) NotFoundHandler(w ResponseWriter, r *Request) error {
 
 // AdminHandler affiche la liste des RSVP (protégé par mot de passe)
 func (h *Handlers) AdminHandler(w ResponseWriter, r *Request) error {
-	// Vérifier si l'admin est configuré
-	if h.adminPassword == "" || h.adminUsername == "" {
-		w.WriteHeader(http.StatusNotFound)
-		return nil
-	}
-
-	// Vérifier l'authentification
-	username, password, ok := r.BasicAuth()
-	if !ok || username != h.adminUsername || password != h.adminPassword {
-		w.Header().Set("WWW-Authenticate", `Basic realm="Administration - RSVP Mariage"`)
-		w.WriteHeader(http.StatusUnauthorized)
-		w.Write([]byte("Authentification requise"))
+	if !h.requireAdmin(w, r) {
 		return nil
 	}
 
@@ -400,6 +785,29 @@ func (h *Handlers) AdminHandler(w ResponseWriter, r *Request) error {
 		}
 	}
 
+	// Besoins d'hébergement et blocs de chambres
+	demand, err := h.accommodationService.Demand()
+	if err != nil {
+		return err
+	}
+	// L'administration est en français
+	accommodations, err := h.accommodationService.ListAccommodations(i18n.FR)
+	if err != nil {
+		return err
+	}
+
+	// Messages du livre d'or à modérer
+	guestbookPending, err := h.guestbookService.Pending()
+	if err != nil {
+		return err
+	}
+
+	// Photos à modérer
+	photosPending, err := h.photoService.Pending()
+	if err != nil {
+		return err
+	}
+
 	data := map[string]interface{}{
 		"Title":          "Administration - RSVPs",
 		"RSVPs":          rsvps,
@@ -409,6 +817,28 @@ func (h *Handlers) AdminHandler(w ResponseWriter, r *Request) error {
 		"TotalPersonnes": totalPersonnes,
 		"TotalAdultes":   totalAdultes,
 		"TotalEnfants":   totalEnfants,
+		"Demand":         demand,
+		"Accommodations": accommodations,
+		"RoomsBlocked":   h.accommodationService.RoomsBlocked(accommodations),
+		"GuestbookQueue": len(guestbookPending),
+		"PhotoQueue":     len(photosPending),
+		"Now":            time.Now(),
+	}
+
+	// Campagnes de relance des invitations sans réponse
+	if h.reminderService != nil {
+		campaigns, err := h.reminderService.Campaigns(time.Now())
+		if err != nil {
+			return err
+		}
+		csrfToken, err := h.csrfManager.GenerateToken(getOrCreateSession(w, r.Request))
+		if err != nil {
+			return err
+		}
+		data["Campaigns"] = campaigns
+		data["CSRFToken"] = csrfToken
+		data["RemindersSent"] = r.URL.Query().Get("reminders_sent")
+		data["ReminderError"] = r.URL.Query().Get("error") == "reminders"
 	}
 
 	return h.templates.ExecuteTemplate(w, "admin.html", data)
@@ -416,18 +846,7 @@ func (h *Handlers) AdminHandler(w ResponseWriter, r *Request) error {
 
 // AdminExportHandler génère et télécharge un fichier Excel des RSVP
 func (h *Handlers) AdminExportHandler(w ResponseWriter, r *Request) error {
-	// Vérifier si l'admin est configuré
-	if h.adminPassword == "" || h.adminUsername == "" {
-		w.WriteHeader(http.StatusNotFound)
-		return nil
-	}
-
-	// Vérifier l'authentification
-	username, password, ok := r.BasicAuth()
-	if !ok || username != h.adminUsername || password != h.adminPassword {
-		w.Header().Set("WWW-Authenticate", `Basic realm="Administration - RSVP Mariage"`)
-		w.WriteHeader(http.StatusUnauthorized)
-		w.Write([]byte("Authentification requise"))
+	if !h.requireAdmin(w, r) {
 		return nil
 	}
 
@@ -451,18 +870,7 @@ func (h *Handlers) AdminExportHandler(w ResponseWriter, r *Request) error {
 
 // AdminDeleteHandler supprime un RSVP
 func (h *Handlers) AdminDeleteHandler(w ResponseWriter, r *Request) error {
-	// Vérifier si l'admin est configuré
-	if h.adminPassword == "" || h.adminUsername == "" {
-		w.WriteHeader(http.StatusNotFound)
-		return nil
-	}
-
-	// Vérifier l'authentification
-	username, password, ok := r.BasicAuth()
-	if !ok || username != h.adminUsername || password != h.adminPassword {
-		w.Header().Set("WWW-Authenticate", `Basic realm="Administration - RSVP Mariage"`)
-		w.WriteHeader(http.StatusUnauthorized)
-		w.Write([]byte("Authentification requise"))
+	if !h.requireAdmin(w, r) {
 		return nil
 	}
 
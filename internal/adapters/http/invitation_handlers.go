@@ -0,0 +1,205 @@
+package http
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"wedding-web/internal/application"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/i18n"
+)
+
+// invitationCookieName est le cookie qui mémorise le code d'invitation du visiteur
+const invitationCookieName = "wedding_invitation"
+
+// invitationView associe une invitation à ses liens personnels
+type invitationView struct {
+	ID      string
+	Name    string
+	Code    string
+	Groups  []string
+	Email   string
+	Lang    string
+	LinkURL string
+	FeedURL template.URL
+}
+
+// currentInvitation identifie l'invité courant.
+// Un lien personnel (?invite=<code>) mémorise le code dans un cookie ;
+// les visites suivantes sont reconnues grâce à ce cookie.
+func (h *Handlers) currentInvitation(w ResponseWriter, r *Request) *domain.Invitation {
+	if code := r.URL.Query().Get("invite"); code != "" {
+		invitation, err := h.invitationService.GetInvitationByCode(code)
+		if err != nil {
+			return nil
+		}
+		setInvitationCookie(w, invitation.Code, 365*24*60*60)
+		return invitation
+	}
+
+	cookie, err := r.Cookie(invitationCookieName)
+	if err != nil || cookie.Value == "" {
+		return nil
+	}
+
+	invitation, err := h.invitationService.GetInvitationByCode(cookie.Value)
+	if err != nil {
+		// Invitation supprimée entre-temps : oublier le cookie
+		setInvitationCookie(w, "", -1)
+		return nil
+	}
+	return invitation
+}
+
+// setInvitationCookie définit (ou supprime avec maxAge < 0) le cookie d'invitation
+func setInvitationCookie(w http.ResponseWriter, code string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     invitationCookieName,
+		Value:    code,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// ForgetInvitationHandler oublie l'invitation mémorisée sur cet appareil
+func (h *Handlers) ForgetInvitationHandler(w ResponseWriter, r *Request) error {
+	setInvitationCookie(w, "", -1)
+	http.Redirect(w, r.Request, "/", http.StatusSeeOther)
+	return nil
+}
+
+// AdminInvitationsHandler affiche la liste des invitations et le formulaire de création
+func (h *Handlers) AdminInvitationsHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	h.reloadTemplates()
+
+	invitations, err := h.invitationService.ListInvitations()
+	if err != nil {
+		return err
+	}
+
+	views := make([]invitationView, 0, len(invitations))
+	for _, invitation := range invitations {
+		views = append(views, invitationView{
+			ID:      invitation.ID,
+			Name:    invitation.Name,
+			Code:    invitation.Code,
+			Groups:  invitation.Groups,
+			Email:   invitation.Email,
+			Lang:    invitation.Lang,
+			LinkURL: h.baseURL + "/?invite=" + url.QueryEscape(invitation.Code),
+			FeedURL: h.calendarFeedURL(invitation.Code, ""),
+		})
+	}
+
+	// Token CSRF pour le formulaire de création
+	sessionID := getOrCreateSession(w, r.Request)
+	csrfToken, err := h.csrfManager.GenerateToken(sessionID)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"Title":       "Administration - Invitations",
+		"Invitations": views,
+		"CSRFToken":   csrfToken,
+		"Locales":     i18n.Locales(),
+		"Error":       r.URL.Query().Get("error"),
+	}
+
+	return h.templates.ExecuteTemplate(w, "admin_invitations.html", data)
+}
+
+// AdminInvitationCreateHandler crée une invitation
+func (h *Handlers) AdminInvitationCreateHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Formulaire invalide"))
+		return nil
+	}
+
+	if !h.verifyCSRF(w, r) {
+		return nil
+	}
+
+	_, err := h.invitationService.CreateInvitation(r.FormValue("name"), r.FormValue("groups"), r.FormValue("email"), r.FormValue("lang"))
+	if errors.Is(err, domain.ErrInvalidEmail) {
+		http.Redirect(w, r.Request, "/admin/invitations?error=email", http.StatusSeeOther)
+		return nil
+	}
+	if err != nil {
+		http.Redirect(w, r.Request, "/admin/invitations?error=invalid", http.StatusSeeOther)
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/invitations", http.StatusSeeOther)
+	return nil
+}
+
+// AdminInvitationContactHandler modifie l'adresse et la langue d'un foyer
+func (h *Handlers) AdminInvitationContactHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Formulaire invalide"))
+		return nil
+	}
+
+	if !h.verifyCSRF(w, r) {
+		return nil
+	}
+
+	err := h.invitationService.UpdateContact(r.FormValue("id"), r.FormValue("email"), r.FormValue("lang"))
+	switch {
+	case errors.Is(err, domain.ErrInvalidEmail):
+		http.Redirect(w, r.Request, "/admin/invitations?error=email", http.StatusSeeOther)
+		return nil
+	case errors.Is(err, application.ErrInvitationNotFound):
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("Invitation introuvable"))
+		return nil
+	case err != nil:
+		return err
+	}
+
+	http.Redirect(w, r.Request, "/admin/invitations", http.StatusSeeOther)
+	return nil
+}
+
+// AdminInvitationDeleteHandler supprime une invitation
+func (h *Handlers) AdminInvitationDeleteHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	// Récupérer l'ID depuis l'URL
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("ID manquant"))
+		return nil
+	}
+
+	if err := h.invitationService.DeleteInvitation(id); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Erreur lors de la suppression"))
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/invitations", http.StatusSeeOther)
+	return nil
+}
@@ -49,6 +49,11 @@ func (w *responseWriter) Status() int {
 	return w.status
 }
 
+// Unwrap donne accès au ResponseWriter d'origine (http.ResponseController)
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 func (w *responseWriter) Written() int {
 	return w.written
 }
@@ -222,6 +227,24 @@ func maxBytesMiddleware(maxBytes int64) Middleware {
 	}
 }
 
+// uploadDeadlineMiddleware allonge les délais de lecture et d'écriture de la
+// connexion, pour laisser le temps d'envoyer un gros fichier depuis un mobile
+func uploadDeadlineMiddleware(timeout time.Duration) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(w ResponseWriter, r *Request) error {
+			deadline := time.Now().Add(timeout)
+			rc := http.NewResponseController(w)
+			if err := rc.SetReadDeadline(deadline); err != nil {
+				return err
+			}
+			if err := rc.SetWriteDeadline(deadline); err != nil {
+				return err
+			}
+			return next(w, r)
+		}
+	}
+}
+
 // timeoutMiddleware ajoute un timeout aux requêtes
 func timeoutMiddleware(timeout time.Duration) Middleware {
 	return func(next HandlerFunc) HandlerFunc {
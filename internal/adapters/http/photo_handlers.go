@@ -0,0 +1,244 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"wedding-web/internal/application"
+	"wedding-web/internal/domain"
+)
+
+// maxPhotosPerUpload limite le nombre de photos d'un même envoi
+const maxPhotosPerUpload = 20
+
+// photoErrorKeys associe les erreurs d'envoi de photos à leur traduction
+var photoErrorKeys = errorKeys{
+	{domain.ErrInvalidName, "error.invalid_name"},
+	{domain.ErrMessageTooLong, "error.caption_too_long"},
+	{domain.ErrLinkNotAllowed, "error.link_not_allowed"},
+	{domain.ErrForbiddenContent, "error.forbidden_content"},
+	{domain.ErrInvalidPhoto, "error.invalid_photo"},
+	{domain.ErrPhotoTooLarge, "error.photo_too_large"},
+	{domain.ErrUnsupportedPhotoType, "error.unsupported_photo"},
+	{application.ErrInvitationRequired, "photos.invitation_required"},
+}
+
+// PhotosHandler affiche la galerie et, pour les invités identifiés, le formulaire d'envoi
+func (h *Handlers) PhotosHandler(w ResponseWriter, r *Request) error {
+	return h.renderPhotos(w, r, "", http.StatusOK)
+}
+
+// PhotosUploadHandler enregistre les photos envoyées par un foyer invité,
+// publiées après modération
+func (h *Handlers) PhotosUploadHandler(w ResponseWriter, r *Request) error {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Content-Type invalide"))
+		return nil
+	}
+
+	t := h.getTranslations(r, w)
+
+	// Les fichiers au-delà de 8 Mo en mémoire sont écrits sur disque temporairement
+	if err := r.ParseMultipartForm(8 << 20); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return h.renderPhotos(w, r, t.T("error.upload_too_large"), http.StatusRequestEntityTooLarge)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Formulaire invalide"))
+		return nil
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	if !h.checkGuestForm(w, r) {
+		return nil
+	}
+
+	invitation := h.currentInvitation(w, r)
+	if invitation == nil {
+		return h.renderPhotos(w, r, t.T("photos.invitation_required"), http.StatusForbidden)
+	}
+
+	files := r.MultipartForm.File["photos"]
+	if len(files) == 0 || len(files) > maxPhotosPerUpload {
+		return h.renderPhotos(w, r, t.T("error.invalid_photo"), http.StatusBadRequest)
+	}
+
+	uploaded := 0
+	for _, header := range files {
+		file, err := header.Open()
+		if err != nil {
+			return err
+		}
+		// Lire un octet de plus que la limite pour détecter les fichiers trop gros
+		data, err := io.ReadAll(io.LimitReader(file, domain.MaxPhotoSize+1))
+		file.Close()
+		if err != nil {
+			return err
+		}
+
+		// Le format est détecté d'après le contenu, pas d'après le nom ou l'en-tête envoyé
+		_, err = h.photoService.Upload(
+			invitation,
+			r.FormValue("name"),
+			r.FormValue("caption"),
+			http.DetectContentType(data),
+			data,
+		)
+		if err != nil {
+			msg := t.T(photoErrorKeys.key(err))
+			if uploaded > 0 {
+				msg = header.Filename + " : " + msg
+			}
+			return h.renderPhotos(w, r, msg, http.StatusBadRequest)
+		}
+		uploaded++
+	}
+
+	http.Redirect(w, r.Request, "/photos?sent="+strconv.Itoa(uploaded), http.StatusSeeOther)
+	return nil
+}
+
+// PhotoImageHandler sert une photo publiée ou sa miniature (?thumb=1)
+func (h *Handlers) PhotoImageHandler(w ResponseWriter, r *Request) error {
+	data, err := h.photoService.PublicImage(r.URL.Query().Get("id"), r.URL.Query().Get("thumb") != "")
+	if err != nil {
+		return h.NotFoundHandler(w, r)
+	}
+
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	_, err = w.Write(data)
+	return err
+}
+
+// renderPhotos affiche la galerie photos
+func (h *Handlers) renderPhotos(w ResponseWriter, r *Request, errorMsg string, status int) error {
+	h.reloadTemplates()
+
+	t := h.getTranslations(r, w)
+	invitation := h.currentInvitation(w, r)
+
+	photos, err := h.photoService.Approved()
+	if err != nil {
+		return err
+	}
+
+	sessionID := getOrCreateSession(w, r.Request)
+	csrfToken, err := h.csrfManager.GenerateToken(sessionID)
+	if err != nil {
+		return err
+	}
+
+	sent, _ := strconv.Atoi(r.URL.Query().Get("sent"))
+
+	data := map[string]interface{}{
+		"Title":     t.T("nav.photos"),
+		"Guest":     invitation,
+		"Photos":    photos,
+		"Sent":      sent,
+		"MaxPhotos": maxPhotosPerUpload,
+		"Error":     errorMsg,
+		"CSRFToken": csrfToken,
+		"T":         t,
+		"Lang":      t.Lang(),
+		"Path":      r.URL.Path,
+	}
+
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+	}
+	return h.templates.ExecuteTemplate(w, "photos.html", data)
+}
+
+// AdminPhotosHandler affiche la file de modération et la galerie publiée
+func (h *Handlers) AdminPhotosHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	h.reloadTemplates()
+
+	pending, err := h.photoService.Pending()
+	if err != nil {
+		return err
+	}
+	approved, err := h.photoService.Approved()
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"Title":    "Administration - Photos",
+		"Pending":  pending,
+		"Approved": approved,
+	}
+
+	return h.templates.ExecuteTemplate(w, "admin_photos.html", data)
+}
+
+// AdminPhotoImageHandler sert une photo, publiée ou non, ou sa miniature (?thumb=1)
+func (h *Handlers) AdminPhotoImageHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	data, err := h.photoService.Image(r.URL.Query().Get("id"), r.URL.Query().Get("thumb") != "")
+	if err != nil {
+		return h.NotFoundHandler(w, r)
+	}
+
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.Header().Set("Cache-Control", "private, no-store")
+	_, err = w.Write(data)
+	return err
+}
+
+// AdminPhotoApproveHandler publie une photo dans la galerie
+func (h *Handlers) AdminPhotoApproveHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("ID manquant"))
+		return nil
+	}
+
+	if err := h.photoService.Approve(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("Photo introuvable"))
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/photos", http.StatusSeeOther)
+	return nil
+}
+
+// AdminPhotoDeleteHandler refuse ou retire une photo (fichiers supprimés)
+func (h *Handlers) AdminPhotoDeleteHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("ID manquant"))
+		return nil
+	}
+
+	if err := h.photoService.DeletePhoto(id); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Erreur lors de la suppression"))
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/photos", http.StatusSeeOther)
+	return nil
+}
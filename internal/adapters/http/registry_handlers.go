@@ -0,0 +1,263 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"wedding-web/internal/application"
+	"wedding-web/internal/domain"
+)
+
+// registryErrorKeys associe les erreurs de réservation à leur traduction
+var registryErrorKeys = errorKeys{
+	{domain.ErrInvalidName, "error.invalid_name"},
+	{domain.ErrInvalidContribution, "error.invalid_contribution"},
+	{domain.ErrGiftAlreadyReserved, "error.gift_already_reserved"},
+	{application.ErrRegistryItemNotFound, "error.gift_not_found"},
+}
+
+// registryItemView associe un cadeau aux réservations du foyer invité
+type registryItemView struct {
+	*domain.RegistryItem
+	OwnReservations []domain.GiftReservation
+}
+
+// RegistryHandler affiche la liste de mariage
+func (h *Handlers) RegistryHandler(w ResponseWriter, r *Request) error {
+	return h.renderRegistry(w, r, "", http.StatusOK)
+}
+
+// RegistryReserveHandler réserve un cadeau ou enregistre une participation à la cagnotte
+func (h *Handlers) RegistryReserveHandler(w ResponseWriter, r *Request) error {
+	if !h.parseGuestForm(w, r) {
+		return nil
+	}
+
+	amount, _ := strconv.Atoi(r.FormValue("amount"))
+	_, err := h.registryService.Reserve(
+		h.currentInvitation(w, r),
+		r.FormValue("item"),
+		r.FormValue("name"),
+		r.FormValue("anonymous") == "yes",
+		amount,
+	)
+	if err != nil {
+		t := h.getTranslations(r, w)
+		status := http.StatusBadRequest
+		if err == domain.ErrGiftAlreadyReserved {
+			status = http.StatusConflict
+		}
+		return h.renderRegistry(w, r, t.T(registryErrorKeys.key(err)), status)
+	}
+
+	http.Redirect(w, r.Request, "/registry?sent=1", http.StatusSeeOther)
+	return nil
+}
+
+// RegistryReleaseHandler annule une réservation du foyer invité
+func (h *Handlers) RegistryReleaseHandler(w ResponseWriter, r *Request) error {
+	if !h.parseGuestForm(w, r) {
+		return nil
+	}
+
+	invitation := h.currentInvitation(w, r)
+	if err := h.registryService.ReleaseOwn(invitation, r.FormValue("item"), r.FormValue("reservation")); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("Réservation introuvable"))
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/registry", http.StatusSeeOther)
+	return nil
+}
+
+// renderRegistry affiche la page de la liste de mariage
+func (h *Handlers) renderRegistry(w ResponseWriter, r *Request, errorMsg string, status int) error {
+	h.reloadTemplates()
+
+	t := h.getTranslations(r, w)
+	invitation := h.currentInvitation(w, r)
+
+	items, err := h.registryService.ListItems()
+	if err != nil {
+		return err
+	}
+
+	views := make([]registryItemView, 0, len(items))
+	for _, item := range items {
+		view := registryItemView{RegistryItem: item}
+		for _, reservation := range item.Reservations {
+			if invitation != nil && reservation.InvitationID == invitation.ID {
+				view.OwnReservations = append(view.OwnReservations, reservation)
+			}
+		}
+		views = append(views, view)
+	}
+
+	sessionID := getOrCreateSession(w, r.Request)
+	csrfToken, err := h.csrfManager.GenerateToken(sessionID)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"Title":     t.T("nav.registry"),
+		"Guest":     invitation,
+		"Items":     views,
+		"Sent":      r.URL.Query().Get("sent") != "",
+		"Error":     errorMsg,
+		"CSRFToken": csrfToken,
+		"T":         t,
+		"Lang":      t.Lang(),
+		"Path":      r.URL.Path,
+	}
+
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+	}
+	return h.templates.ExecuteTemplate(w, "registry.html", data)
+}
+
+// AdminRegistryHandler affiche la liste de mariage, ses réservations et le formulaire d'ajout
+func (h *Handlers) AdminRegistryHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	h.reloadTemplates()
+
+	items, err := h.registryService.ListItems()
+	if err != nil {
+		return err
+	}
+
+	collected := 0
+	for _, item := range items {
+		collected += item.Collected()
+	}
+
+	// Token CSRF pour le formulaire d'ajout
+	sessionID := getOrCreateSession(w, r.Request)
+	csrfToken, err := h.csrfManager.GenerateToken(sessionID)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"Title":     "Administration - Liste de mariage",
+		"Items":     items,
+		"Collected": collected,
+		"CSRFToken": csrfToken,
+		"Error":     r.URL.Query().Get("error"),
+	}
+
+	return h.templates.ExecuteTemplate(w, "admin_registry.html", data)
+}
+
+// AdminRegistryCreateHandler ajoute un cadeau ou une cagnotte
+func (h *Handlers) AdminRegistryCreateHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Formulaire invalide"))
+		return nil
+	}
+
+	if !h.verifyCSRF(w, r) {
+		return nil
+	}
+
+	price, _ := strconv.Atoi(r.FormValue("price"))
+	_, err := h.registryService.AddItem(
+		r.FormValue("title"),
+		r.FormValue("description"),
+		r.FormValue("image_url"),
+		price,
+		r.FormValue("fund") == "yes",
+	)
+	if err == domain.ErrInvalidGiftImage {
+		http.Redirect(w, r.Request, "/admin/registry?error=image", http.StatusSeeOther)
+		return nil
+	}
+	if err != nil {
+		http.Redirect(w, r.Request, "/admin/registry?error=invalid", http.StatusSeeOther)
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/registry", http.StatusSeeOther)
+	return nil
+}
+
+// AdminRegistryDeleteHandler supprime un cadeau et ses réservations
+func (h *Handlers) AdminRegistryDeleteHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	// Récupérer l'ID depuis l'URL
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("ID manquant"))
+		return nil
+	}
+
+	if err := h.registryService.DeleteItem(id); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Erreur lors de la suppression"))
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/registry", http.StatusSeeOther)
+	return nil
+}
+
+// AdminRegistryReleaseHandler annule une réservation
+func (h *Handlers) AdminRegistryReleaseHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	id := r.URL.Query().Get("id")
+	reservation := r.URL.Query().Get("reservation")
+	if id == "" || reservation == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("ID manquant"))
+		return nil
+	}
+
+	if err := h.registryService.ReleaseReservation(id, reservation); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("Réservation introuvable"))
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/registry", http.StatusSeeOther)
+	return nil
+}
+
+// AdminRegistryExportHandler exporte qui offre quoi en Excel, pour les remerciements
+func (h *Handlers) AdminRegistryExportHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	items, err := h.registryService.ListItems()
+	if err != nil {
+		return err
+	}
+
+	file, err := h.exportService.ExportRegistryToExcel(items)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", h.exportService.GetRegistryFileName()))
+
+	return file.Write(w)
+}
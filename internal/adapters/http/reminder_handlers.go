@@ -0,0 +1,48 @@
+package http
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"wedding-web/internal/application"
+)
+
+// AdminReminderSendHandler envoie tout de suite une campagne de relance
+// aux foyers sans réponse qu'elle n'a pas encore relancés
+func (h *Handlers) AdminReminderSendHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	if h.reminderService == nil {
+		return h.NotFoundHandler(w, r)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Formulaire invalide"))
+		return nil
+	}
+
+	if !h.verifyCSRF(w, r) {
+		return nil
+	}
+
+	sent, err := h.reminderService.SendCampaign(r.FormValue("id"), time.Now())
+	if errors.Is(err, application.ErrReminderCampaignNotFound) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Campagne inconnue"))
+		return nil
+	}
+	if err != nil {
+		// Les relances parties sont enregistrées : un nouvel envoi complète les autres
+		log.Printf("Erreur lors de l'envoi de la relance: %v", err)
+		http.Redirect(w, r.Request, "/admin?reminders_sent="+strconv.Itoa(sent)+"&error=reminders#relances", http.StatusSeeOther)
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin?reminders_sent="+strconv.Itoa(sent)+"#relances", http.StatusSeeOther)
+	return nil
+}
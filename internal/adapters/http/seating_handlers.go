@@ -0,0 +1,220 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"wedding-web/internal/application"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/i18n"
+)
+
+// AdminSeatingHandler affiche le plan de table : tables, invités à placer et avertissements
+func (h *Handlers) AdminSeatingHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	h.reloadTemplates()
+
+	plan, err := h.seatingService.Plan()
+	if err != nil {
+		return err
+	}
+
+	// Token CSRF pour les formulaires de placement
+	sessionID := getOrCreateSession(w, r.Request)
+	csrfToken, err := h.csrfManager.GenerateToken(sessionID)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"Title":     "Administration - Plan de table",
+		"Plan":      plan,
+		"CSRFToken": csrfToken,
+		"Error":     r.URL.Query().Get("error"),
+	}
+
+	return h.templates.ExecuteTemplate(w, "admin_seating.html", data)
+}
+
+// AdminSeatingPrintHandler affiche la liste des tables, à imprimer
+func (h *Handlers) AdminSeatingPrintHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	h.reloadTemplates()
+
+	plan, err := h.seatingService.Plan()
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"Title": "Plan de table",
+		"Plan":  plan,
+	}
+
+	return h.templates.ExecuteTemplate(w, "admin_seating_print.html", data)
+}
+
+// AdminSeatingPDFHandler télécharge un document à imprimer du plan de table
+// (?document=escort|place|plan). La langue choisie s'applique au plan et aux
+// invités dont la langue n'est pas connue.
+func (h *Handlers) AdminSeatingPDFHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	document := application.PrintDocument(r.URL.Query().Get("document"))
+	lang := i18n.NewTranslations(i18n.Lang(r.URL.Query().Get("lang"))).Lang()
+
+	// Générer le document avant d'envoyer les headers de téléchargement
+	var buf bytes.Buffer
+	err := h.printService.WritePDF(&buf, document, lang)
+	if err == application.ErrUnknownDocument {
+		return h.NotFoundHandler(w, r)
+	}
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", h.printService.GetFileName(document)))
+	w.Write(buf.Bytes())
+	return nil
+}
+
+// AdminSeatingTableCreateHandler ajoute une table
+func (h *Handlers) AdminSeatingTableCreateHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Formulaire invalide"))
+		return nil
+	}
+
+	if !h.verifyCSRF(w, r) {
+		return nil
+	}
+
+	capacity, _ := strconv.Atoi(r.FormValue("capacity"))
+	if _, err := h.seatingService.AddTable(r.FormValue("name"), capacity); err != nil {
+		http.Redirect(w, r.Request, "/admin/seating?error=table", http.StatusSeeOther)
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/seating", http.StatusSeeOther)
+	return nil
+}
+
+// AdminSeatingTableUpdateHandler renomme une table ou change sa capacité
+func (h *Handlers) AdminSeatingTableUpdateHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Formulaire invalide"))
+		return nil
+	}
+
+	if !h.verifyCSRF(w, r) {
+		return nil
+	}
+
+	capacity, _ := strconv.Atoi(r.FormValue("capacity"))
+	if err := h.seatingService.UpdateTable(r.FormValue("id"), r.FormValue("name"), capacity); err != nil {
+		http.Redirect(w, r.Request, "/admin/seating?error=table", http.StatusSeeOther)
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/seating", http.StatusSeeOther)
+	return nil
+}
+
+// AdminSeatingTableDeleteHandler supprime une table (ses invités redeviennent à placer)
+func (h *Handlers) AdminSeatingTableDeleteHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("ID manquant"))
+		return nil
+	}
+
+	if err := h.seatingService.DeleteTable(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("Table introuvable"))
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/seating", http.StatusSeeOther)
+	return nil
+}
+
+// AdminSeatingAssignHandler place des adultes et des enfants d'un foyer à une table
+func (h *Handlers) AdminSeatingAssignHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Formulaire invalide"))
+		return nil
+	}
+
+	if !h.verifyCSRF(w, r) {
+		return nil
+	}
+
+	adults, _ := strconv.Atoi(r.FormValue("adults"))
+	children, _ := strconv.Atoi(r.FormValue("children"))
+	err := h.seatingService.Assign(r.FormValue("table"), r.FormValue("rsvp"), adults, children)
+	if err == domain.ErrInvalidSeating {
+		http.Redirect(w, r.Request, "/admin/seating?error=seating", http.StatusSeeOther)
+		return nil
+	}
+	if err != nil {
+		http.Redirect(w, r.Request, "/admin/seating?error=table", http.StatusSeeOther)
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/seating", http.StatusSeeOther)
+	return nil
+}
+
+// AdminSeatingUnassignHandler retire un foyer d'une table
+func (h *Handlers) AdminSeatingUnassignHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	table := r.URL.Query().Get("table")
+	rsvp := r.URL.Query().Get("rsvp")
+	if table == "" || rsvp == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("ID manquant"))
+		return nil
+	}
+
+	if err := h.seatingService.Unassign(table, rsvp); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("Table introuvable"))
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/seating", http.StatusSeeOther)
+	return nil
+}
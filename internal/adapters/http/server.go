@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+	"wedding-web/internal/i18n"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -20,10 +21,14 @@ type ServerConfig struct {
 	BasicAuthConfig    BasicAuthConfig
 	RateLimitPerMinute int
 	MaxBodySize        int64
+	MaxUploadSize      int64 // Taille maximale d'un envoi de photos
 	StaticDir          string
 	TemplatesDir       string
 }
 
+// uploadTimeout laisse le temps d'envoyer des photos depuis une connexion lente
+const uploadTimeout = 5 * time.Minute
+
 // Server représente le serveur HTTP
 type Server struct {
 	config   ServerConfig
@@ -48,19 +53,13 @@ func (s *Server) setupRoutes() {
 	rateLimiter := NewRateLimiter(s.config.RateLimitPerMinute)
 
 	// Middlewares globaux
-	globalMiddlewares := []Middleware{
-		requestIDMiddleware(),
-		recoverMiddleware(s.config.IsProd),
-		loggingMiddleware(),
-		securityHeadersMiddleware(s.config.EnableHSTS),
-		maxBytesMiddleware(s.config.MaxBodySize),
-		timeoutMiddleware(30 * time.Second),
-	}
+	globalMiddlewares := s.middlewares(s.config.MaxBodySize, 30*time.Second)
 
-	// Basic Auth si configuré
-	if s.config.BasicAuthConfig.Enabled {
-		globalMiddlewares = append(globalMiddlewares, basicAuthMiddleware(s.config.BasicAuthConfig))
-	}
+	// Envoi de photos : taille de body et délais plus grands, rate limiting strict
+	uploadMiddlewares := append(s.middlewares(s.config.MaxUploadSize, uploadTimeout),
+		uploadDeadlineMiddleware(uploadTimeout),
+		rateLimitMiddleware(rateLimiter),
+	)
 
 	// Fichiers statiques (sans rate limiting pour les assets)
 	fileServer := http.FileServer(http.Dir(s.config.StaticDir))
@@ -77,17 +76,68 @@ func (s *Server) setupRoutes() {
 		r.Get("/planning", s.adaptHandler(s.handlers.PlanningHandler, globalMiddlewares))
 		r.Get("/infos", s.adaptHandler(s.handlers.InfosHandler, globalMiddlewares))
 		r.Get("/calendar.ics", s.adaptHandler(s.handlers.CalendarHandler, globalMiddlewares))
+		r.Get("/calendar/feed.ics", s.adaptHandler(s.handlers.CalendarFeedHandler, globalMiddlewares))
+		r.Get("/calendar/event.ics", s.adaptHandler(s.handlers.EventCalendarHandler, globalMiddlewares))
 		r.Get("/health", s.adaptHandler(s.handlers.HealthHandler, globalMiddlewares))
+		r.Get("/invitation/forget", s.adaptHandler(s.handlers.ForgetInvitationHandler, globalMiddlewares))
 		r.Get("/admin", s.adaptHandler(s.handlers.AdminHandler, globalMiddlewares))
 		r.Get("/admin/export", s.adaptHandler(s.handlers.AdminExportHandler, globalMiddlewares))
 		r.Get("/admin/delete", s.adaptHandler(s.handlers.AdminDeleteHandler, globalMiddlewares))
+		r.Get("/admin/invitations", s.adaptHandler(s.handlers.AdminInvitationsHandler, globalMiddlewares))
+		r.Post("/admin/invitations", s.adaptHandler(s.handlers.AdminInvitationCreateHandler, globalMiddlewares))
+		r.Post("/admin/invitations/contact", s.adaptHandler(s.handlers.AdminInvitationContactHandler, globalMiddlewares))
+		r.Get("/admin/invitations/delete", s.adaptHandler(s.handlers.AdminInvitationDeleteHandler, globalMiddlewares))
+		r.Post("/admin/reminders/send", s.adaptHandler(s.handlers.AdminReminderSendHandler, globalMiddlewares))
+		r.Get("/admin/carpool", s.adaptHandler(s.handlers.AdminCarpoolHandler, globalMiddlewares))
+		r.Get("/admin/carpool/delete", s.adaptHandler(s.handlers.AdminCarpoolDeleteHandler, globalMiddlewares))
+		r.Get("/admin/carpool/export", s.adaptHandler(s.handlers.AdminCarpoolExportHandler, globalMiddlewares))
+		r.Get("/admin/songs", s.adaptHandler(s.handlers.AdminSongsHandler, globalMiddlewares))
+		r.Get("/admin/songs/delete", s.adaptHandler(s.handlers.AdminSongDeleteHandler, globalMiddlewares))
+		r.Get("/admin/songs/export", s.adaptHandler(s.handlers.AdminSongsExportHandler, globalMiddlewares))
+		r.Get("/admin/guestbook", s.adaptHandler(s.handlers.AdminGuestbookHandler, globalMiddlewares))
+		r.Get("/admin/guestbook/approve", s.adaptHandler(s.handlers.AdminGuestbookApproveHandler, globalMiddlewares))
+		r.Get("/admin/guestbook/delete", s.adaptHandler(s.handlers.AdminGuestbookDeleteHandler, globalMiddlewares))
+		r.Get("/admin/registry", s.adaptHandler(s.handlers.AdminRegistryHandler, globalMiddlewares))
+		r.Post("/admin/registry", s.adaptHandler(s.handlers.AdminRegistryCreateHandler, globalMiddlewares))
+		r.Get("/admin/registry/delete", s.adaptHandler(s.handlers.AdminRegistryDeleteHandler, globalMiddlewares))
+		r.Get("/admin/registry/release", s.adaptHandler(s.handlers.AdminRegistryReleaseHandler, globalMiddlewares))
+		r.Get("/admin/registry/export", s.adaptHandler(s.handlers.AdminRegistryExportHandler, globalMiddlewares))
+		r.Get("/admin/photos", s.adaptHandler(s.handlers.AdminPhotosHandler, globalMiddlewares))
+		r.Get("/admin/photos/image", s.adaptHandler(s.handlers.AdminPhotoImageHandler, globalMiddlewares))
+		r.Get("/admin/photos/approve", s.adaptHandler(s.handlers.AdminPhotoApproveHandler, globalMiddlewares))
+		r.Get("/admin/photos/delete", s.adaptHandler(s.handlers.AdminPhotoDeleteHandler, globalMiddlewares))
+		r.Get("/photos/image", s.adaptHandler(s.handlers.PhotoImageHandler, globalMiddlewares))
+		r.Get("/admin/seating", s.adaptHandler(s.handlers.AdminSeatingHandler, globalMiddlewares))
+		r.Get("/admin/seating/print", s.adaptHandler(s.handlers.AdminSeatingPrintHandler, globalMiddlewares))
+		r.Get("/admin/seating/pdf", s.adaptHandler(s.handlers.AdminSeatingPDFHandler, globalMiddlewares))
+		r.Post("/admin/seating/tables", s.adaptHandler(s.handlers.AdminSeatingTableCreateHandler, globalMiddlewares))
+		r.Post("/admin/seating/tables/update", s.adaptHandler(s.handlers.AdminSeatingTableUpdateHandler, globalMiddlewares))
+		r.Get("/admin/seating/tables/delete", s.adaptHandler(s.handlers.AdminSeatingTableDeleteHandler, globalMiddlewares))
+		r.Post("/admin/seating/assign", s.adaptHandler(s.handlers.AdminSeatingAssignHandler, globalMiddlewares))
+		r.Get("/admin/seating/unassign", s.adaptHandler(s.handlers.AdminSeatingUnassignHandler, globalMiddlewares))
+		r.Get("/admin/thanks", s.adaptHandler(s.handlers.AdminThanksHandler, globalMiddlewares))
+		r.Post("/admin/thanks", s.adaptHandler(s.handlers.AdminThanksSaveHandler, globalMiddlewares))
+		r.Get("/admin/thanks/sent", s.adaptHandler(s.handlers.AdminThanksSentHandler, globalMiddlewares))
+		r.Get("/admin/thanks/export", s.adaptHandler(s.handlers.AdminThanksExportHandler, globalMiddlewares))
 	})
 
-	// Routes RSVP avec rate limiting strict
+	// Routes des formulaires invités (RSVP, covoiturage, musique, livre d'or, liste de mariage, photos) avec rate limiting strict
 	r.Group(func(r chi.Router) {
 		strictMiddlewares := append(globalMiddlewares, rateLimitMiddleware(rateLimiter))
 		r.Get("/rsvp", s.adaptHandler(s.handlers.RSVPGetHandler, strictMiddlewares))
 		r.Post("/rsvp", s.adaptHandler(s.handlers.RSVPPostHandler, strictMiddlewares))
+		r.Get("/carpool", s.adaptHandler(s.handlers.CarpoolHandler, strictMiddlewares))
+		r.Post("/carpool", s.adaptHandler(s.handlers.CarpoolPostHandler, strictMiddlewares))
+		r.Post("/carpool/withdraw", s.adaptHandler(s.handlers.CarpoolWithdrawHandler, strictMiddlewares))
+		r.Get("/songs", s.adaptHandler(s.handlers.SongsHandler, strictMiddlewares))
+		r.Post("/songs", s.adaptHandler(s.handlers.SongsPostHandler, strictMiddlewares))
+		r.Get("/guestbook", s.adaptHandler(s.handlers.GuestbookHandler, strictMiddlewares))
+		r.Post("/guestbook", s.adaptHandler(s.handlers.GuestbookPostHandler, strictMiddlewares))
+		r.Get("/registry", s.adaptHandler(s.handlers.RegistryHandler, strictMiddlewares))
+		r.Post("/registry/reserve", s.adaptHandler(s.handlers.RegistryReserveHandler, strictMiddlewares))
+		r.Post("/registry/release", s.adaptHandler(s.handlers.RegistryReleaseHandler, strictMiddlewares))
+		r.Get("/photos", s.adaptHandler(s.handlers.PhotosHandler, strictMiddlewares))
+		r.Post("/photos", s.adaptHandler(s.handlers.PhotosUploadHandler, uploadMiddlewares))
 	})
 
 	// 404 handler
@@ -96,6 +146,25 @@ func (s *Server) setupRoutes() {
 	s.router = r
 }
 
+// middlewares retourne la chaîne de middlewares commune à toutes les routes,
+// avec la taille de body maximale et le timeout de la route
+func (s *Server) middlewares(maxBodySize int64, timeout time.Duration) []Middleware {
+	middlewares := []Middleware{
+		requestIDMiddleware(),
+		recoverMiddleware(s.config.IsProd),
+		loggingMiddleware(),
+		securityHeadersMiddleware(s.config.EnableHSTS),
+		maxBytesMiddleware(maxBodySize),
+		timeoutMiddleware(timeout),
+	}
+
+	// Basic Auth si configuré
+	if s.config.BasicAuthConfig.Enabled {
+		middlewares = append(middlewares, basicAuthMiddleware(s.config.BasicAuthConfig))
+	}
+	return middlewares
+}
+
 // adaptHandler adapte un HandlerFunc custom vers http.Handler
 func (s *Server) adaptHandler(h HandlerFunc, middlewares []Middleware) http.HandlerFunc {
 	handler := Chain(h, middlewares...)
@@ -139,7 +208,7 @@ func (s *Server) Start() error {
 
 	s.server = &http.Server{
 		Addr:              fmt.Sprintf(":%d", s.config.Port),
-		Handler:           s.router,
+		Handler:           i18n.URLPrefixHandler(s.router),
 		ReadTimeout:       10 * time.Second,
 		ReadHeaderTimeout: 5 * time.Second,
 		WriteTimeout:      30 * time.Second,
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"wedding-web/internal/domain"
+)
+
+// songErrorKeys associe les erreurs de suggestion à leur traduction
+var songErrorKeys = errorKeys{
+	{domain.ErrInvalidName, "error.invalid_name"},
+	{domain.ErrInvalidSong, "error.invalid_song"},
+	{domain.ErrInvalidSongLink, "error.invalid_song_link"},
+}
+
+// SongsHandler affiche le formulaire de suggestions musicales
+func (h *Handlers) SongsHandler(w ResponseWriter, r *Request) error {
+	return h.renderSongs(w, r, "", http.StatusOK)
+}
+
+// SongsPostHandler enregistre un morceau proposé pour la soirée
+func (h *Handlers) SongsPostHandler(w ResponseWriter, r *Request) error {
+	if !h.parseGuestForm(w, r) {
+		return nil
+	}
+
+	_, err := h.songService.SuggestSong(
+		h.currentInvitation(w, r),
+		"",
+		r.FormValue("name"),
+		r.FormValue("title"),
+		r.FormValue("artist"),
+		r.FormValue("link"),
+	)
+	if err != nil {
+		t := h.getTranslations(r, w)
+		return h.renderSongs(w, r, t.T(songErrorKeys.key(err)), http.StatusBadRequest)
+	}
+
+	http.Redirect(w, r.Request, "/songs?sent=1", http.StatusSeeOther)
+	return nil
+}
+
+// renderSongs affiche la page des suggestions avec les morceaux déjà proposés par le foyer
+func (h *Handlers) renderSongs(w ResponseWriter, r *Request, errorMsg string, status int) error {
+	h.reloadTemplates()
+
+	t := h.getTranslations(r, w)
+	invitation := h.currentInvitation(w, r)
+
+	songs, err := h.songService.SuggestionsOf(invitation)
+	if err != nil {
+		return err
+	}
+
+	sessionID := getOrCreateSession(w, r.Request)
+	csrfToken, err := h.csrfManager.GenerateToken(sessionID)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"Title":     t.T("nav.songs"),
+		"Guest":     invitation,
+		"MySongs":   songs,
+		"Sent":      r.URL.Query().Get("sent") != "",
+		"Error":     errorMsg,
+		"CSRFToken": csrfToken,
+		"T":         t,
+		"Lang":      t.Lang(),
+		"Path":      r.URL.Path,
+	}
+
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+	}
+	return h.templates.ExecuteTemplate(w, "songs.html", data)
+}
+
+// AdminSongsHandler affiche le classement des morceaux proposés
+func (h *Handlers) AdminSongsHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	h.reloadTemplates()
+
+	ranking, err := h.songService.Ranking()
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"Title":   "Administration - Playlist",
+		"Ranking": ranking,
+	}
+
+	return h.templates.ExecuteTemplate(w, "admin_songs.html", data)
+}
+
+// AdminSongDeleteHandler supprime un morceau et toutes ses suggestions
+func (h *Handlers) AdminSongDeleteHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	// Récupérer la clé du morceau depuis l'URL
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Morceau manquant"))
+		return nil
+	}
+
+	if err := h.songService.DeleteSong(key); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Erreur lors de la suppression"))
+		return nil
+	}
+
+	http.Redirect(w, r.Request, "/admin/songs", http.StatusSeeOther)
+	return nil
+}
+
+// AdminSongsExportHandler exporte le classement en CSV ou en playlist M3U (?format=m3u)
+func (h *Handlers) AdminSongsExportHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	ranking, err := h.songService.Ranking()
+	if err != nil {
+		return err
+	}
+
+	if r.URL.Query().Get("format") == "m3u" {
+		w.Header().Set("Content-Type", "audio/x-mpegurl; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", h.exportService.GetSongsFileName("m3u")))
+		return h.exportService.ExportSongsToM3U(w, ranking)
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", h.exportService.GetSongsFileName("csv")))
+	return h.exportService.ExportSongsToCSV(w, ranking)
+}
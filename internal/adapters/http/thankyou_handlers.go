@@ -0,0 +1,130 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"wedding-web/internal/application"
+	"wedding-web/internal/domain"
+)
+
+// thanksURL retourne l'adresse du suivi des remerciements, avec le filtre
+// courant et éventuellement une erreur à afficher
+func thanksURL(filter, errorKey string) string {
+	query := url.Values{}
+	if filter != "" {
+		query.Set("filter", filter)
+	}
+	if errorKey != "" {
+		query.Set("error", errorKey)
+	}
+	if len(query) == 0 {
+		return "/admin/thanks"
+	}
+	return "/admin/thanks?" + query.Encode()
+}
+
+// AdminThanksHandler affiche le suivi des remerciements par foyer
+// (?filter=pending : pas encore remerciés, ?filter=sent : carte envoyée)
+func (h *Handlers) AdminThanksHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	h.reloadTemplates()
+
+	filter := application.ThankYouFilter(r.URL.Query().Get("filter"))
+	households, err := h.thankYouService.Households(filter)
+	if err != nil {
+		return err
+	}
+
+	// Token CSRF pour les formulaires de saisie
+	sessionID := getOrCreateSession(w, r.Request)
+	csrfToken, err := h.csrfManager.GenerateToken(sessionID)
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"Title":      "Administration - Remerciements",
+		"Households": households,
+		"Filter":     string(filter),
+		"CSRFToken":  csrfToken,
+		"Error":      r.URL.Query().Get("error"),
+	}
+
+	return h.templates.ExecuteTemplate(w, "admin_thanks.html", data)
+}
+
+// AdminThanksSaveHandler enregistre le cadeau reçu, la note et l'adresse d'un foyer
+func (h *Handlers) AdminThanksSaveHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Formulaire invalide"))
+		return nil
+	}
+
+	if !h.verifyCSRF(w, r) {
+		return nil
+	}
+
+	filter := r.FormValue("filter")
+	address := domain.PostalAddress{
+		Street:     r.FormValue("street"),
+		PostalCode: r.FormValue("postal_code"),
+		City:       r.FormValue("city"),
+		Country:    r.FormValue("country"),
+	}
+	err := h.thankYouService.Save(r.FormValue("invitation"), r.FormValue("rsvp"), r.FormValue("gift"), r.FormValue("note"), address)
+	if err == domain.ErrInvalidAddress {
+		http.Redirect(w, r.Request, thanksURL(filter, "address"), http.StatusSeeOther)
+		return nil
+	}
+	if err != nil {
+		http.Redirect(w, r.Request, thanksURL(filter, "invalid"), http.StatusSeeOther)
+		return nil
+	}
+
+	http.Redirect(w, r.Request, thanksURL(filter, ""), http.StatusSeeOther)
+	return nil
+}
+
+// AdminThanksSentHandler marque la carte d'un foyer comme envoyée
+// (?sent=0 annule l'envoi)
+func (h *Handlers) AdminThanksSentHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	query := r.URL.Query()
+	if err := h.thankYouService.MarkSent(query.Get("invitation"), query.Get("rsvp"), query.Get("sent") != "0"); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Foyer invalide"))
+		return nil
+	}
+
+	http.Redirect(w, r.Request, thanksURL(query.Get("filter"), ""), http.StatusSeeOther)
+	return nil
+}
+
+// AdminThanksExportHandler exporte le suivi des remerciements en CSV pour
+// un publipostage (adresses postales), avec le filtre courant
+func (h *Handlers) AdminThanksExportHandler(w ResponseWriter, r *Request) error {
+	if !h.requireAdmin(w, r) {
+		return nil
+	}
+
+	households, err := h.thankYouService.Households(application.ThankYouFilter(r.URL.Query().Get("filter")))
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", h.exportService.GetThankYousFileName()))
+	return h.exportService.ExportThankYousToCSV(w, households)
+}
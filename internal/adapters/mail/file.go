@@ -0,0 +1,69 @@
+package mail
+
+import (
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"wedding-web/internal/domain"
+)
+
+// FileMailer écrit les emails dans un répertoire (un fichier .eml par
+// email, lisible par un client mail) au lieu de les envoyer : pour le
+// développement
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer crée un mailer qui dépose les emails dans dir
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &FileMailer{
+		dir:  dir,
+		from: from,
+	}, nil
+}
+
+// Send écrit l'email dans le répertoire
+func (m *FileMailer) Send(email domain.Email) error {
+	from, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("expéditeur invalide %q: %w", m.from, err)
+	}
+	now := time.Now()
+	message, err := buildMessage(from, email, now)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102-150405.000000"), slug(email.Subject))
+	return os.WriteFile(filepath.Join(m.dir, name), message, 0600)
+}
+
+// slug retourne une version du sujet utilisable dans un nom de fichier
+func slug(subject string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(subject) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+		if b.Len() >= 40 {
+			break
+		}
+	}
+	if b.Len() == 0 {
+		return "email"
+	}
+	return strings.Trim(b.String(), "-")
+}
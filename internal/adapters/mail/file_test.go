@@ -0,0 +1,67 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"wedding-web/internal/domain"
+)
+
+func TestFileMailer(t *testing.T) {
+	dir := t.TempDir()
+	mailer, err := NewFileMailer(dir, "mariage@example.com")
+	if err != nil {
+		t.Fatalf("NewFileMailer() error = %v", err)
+	}
+
+	email := domain.Email{
+		To:          []string{"anna@example.com"},
+		Subject:     "Votre réponse",
+		Body:        "Merci Anna !",
+		Attachments: []domain.Attachment{{Filename: "mariage.ics", ContentType: "text/calendar; charset=utf-8", Data: []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")}},
+	}
+	if err := mailer.Send(email); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*-votre-r-ponse.eml"))
+	if len(files) != 1 {
+		entries, _ := os.ReadDir(dir)
+		t.Fatalf("files = %v, want one .eml named after the subject", entries)
+	}
+	data, _ := os.ReadFile(files[0])
+
+	// Message multipart : le texte puis le calendrier en pièce jointe
+	message, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	mediaType, params, _ := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if mediaType != "multipart/mixed" {
+		t.Fatalf("Content-Type = %q, want multipart/mixed", mediaType)
+	}
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	var parts []string
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		content, _ := io.ReadAll(part)
+		if part.Header.Get("Content-Transfer-Encoding") == "base64" {
+			content, _ = base64.StdEncoding.DecodeString(strings.ReplaceAll(string(content), "\r\n", ""))
+		}
+		parts = append(parts, part.FileName()+"|"+string(content))
+	}
+	if len(parts) != 2 || !strings.Contains(parts[0], "Merci Anna") || !strings.HasPrefix(parts[1], "mariage.ics|BEGIN:VCALENDAR") {
+		t.Errorf("parts = %q, want the text and the decoded attachment", parts)
+	}
+}
@@ -0,0 +1,125 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"wedding-web/internal/domain"
+)
+
+// buildMessage compose le message MIME d'un email texte (UTF-8,
+// quoted-printable), en multipart/mixed s'il a des pièces jointes
+func buildMessage(from *mail.Address, email domain.Email, date time.Time) ([]byte, error) {
+	to := make([]string, len(email.To))
+	for i, recipient := range email.To {
+		address, err := mail.ParseAddress(recipient)
+		if err != nil {
+			return nil, fmt.Errorf("destinataire invalide %q: %w", recipient, err)
+		}
+		to[i] = address.String()
+	}
+
+	var buf bytes.Buffer
+	writeHeader(&buf, "From", from.String())
+	writeHeader(&buf, "To", strings.Join(to, ", "))
+	writeHeader(&buf, "Subject", mime.QEncoding.Encode("utf-8", email.Subject))
+	writeHeader(&buf, "Date", date.Format(time.RFC1123Z))
+	writeHeader(&buf, "Message-ID", messageID(from))
+	writeHeader(&buf, "MIME-Version", "1.0")
+
+	if len(email.Attachments) == 0 {
+		writeHeader(&buf, "Content-Type", "text/plain; charset=utf-8")
+		writeHeader(&buf, "Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeText(&buf, email.Body); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&buf)
+	writeHeader(&buf, "Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": parts.Boundary()}))
+	buf.WriteString("\r\n")
+
+	text, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := writeText(text, email.Body); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range email.Attachments {
+		part, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachment.ContentType},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		writeBase64(part, attachment.Data)
+	}
+
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeText écrit le corps du message en quoted-printable
+func writeText(w io.Writer, text string) error {
+	body := quotedprintable.NewWriter(w)
+	if _, err := body.Write([]byte(crlf(text))); err != nil {
+		return err
+	}
+	return body.Close()
+}
+
+// writeBase64 écrit une pièce jointe en base64, par lignes de 76 caractères
+func writeBase64(w io.Writer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		io.WriteString(w, encoded[:76]+"\r\n")
+		encoded = encoded[76:]
+	}
+	io.WriteString(w, encoded+"\r\n")
+}
+
+// writeHeader écrit un en-tête, sans retour à la ligne qui permettrait d'en
+// injecter d'autres
+func writeHeader(buf *bytes.Buffer, name, value string) {
+	value = strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+	fmt.Fprintf(buf, "%s: %s\r\n", name, value)
+}
+
+// messageID génère un identifiant de message unique sur le domaine de l'expéditeur
+func messageID(from *mail.Address) string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	host := "localhost"
+	if at := strings.LastIndex(from.Address, "@"); at >= 0 {
+		host = from.Address[at+1:]
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), host)
+}
+
+// crlf normalise les fins de ligne du corps en CRLF
+func crlf(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.ReplaceAll(text, "\n", "\r\n")
+}
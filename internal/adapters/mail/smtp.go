@@ -0,0 +1,118 @@
+package mail
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"wedding-web/internal/domain"
+)
+
+// smtpTimeout borne la durée d'un envoi (connexion comprise)
+const smtpTimeout = 30 * time.Second
+
+// SMTPMailer envoie les emails par un serveur SMTP : STARTTLS si le serveur
+// le propose, TLS direct sur le port 465, authentification si un
+// utilisateur est configuré
+type SMTPMailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPMailer crée un mailer SMTP ; from est l'expéditeur des emails
+// ("Marie & Paul <mariage@example.com>")
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// Send envoie un email
+func (m *SMTPMailer) Send(email domain.Email) error {
+	from, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("expéditeur invalide %q: %w", m.from, err)
+	}
+	message, err := buildMessage(from, email, time.Now())
+	if err != nil {
+		return err
+	}
+
+	client, err := m.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.host}); err != nil {
+			return err
+		}
+	}
+	if m.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	for _, recipient := range email.To {
+		address, err := mail.ParseAddress(recipient)
+		if err != nil {
+			return fmt.Errorf("destinataire invalide %q: %w", recipient, err)
+		}
+		if err := client.Rcpt(address.Address); err != nil {
+			return err
+		}
+	}
+
+	data, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := data.Write(message); err != nil {
+		return err
+	}
+	if err := data.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// dial ouvre la connexion au serveur SMTP
+func (m *SMTPMailer) dial() (*smtp.Client, error) {
+	addr := net.JoinHostPort(m.host, strconv.Itoa(m.port))
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+
+	var conn net.Conn
+	var err error
+	if m.port == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: m.host})
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	client, err := smtp.NewClient(conn, m.host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return client, nil
+}
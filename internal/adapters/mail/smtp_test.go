@@ -0,0 +1,149 @@
+package mail
+
+import (
+	"bufio"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"wedding-web/internal/domain"
+)
+
+// smtpStandIn est un serveur SMTP minimal qui enregistre les messages reçus
+type smtpStandIn struct {
+	listener   net.Listener
+	recipients []string
+	data       string
+	done       chan struct{}
+}
+
+// startSMTPStandIn démarre le serveur sur un port local, pour une connexion
+func startSMTPStandIn(t *testing.T) *smtpStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	server := &smtpStandIn{listener: listener, done: make(chan struct{})}
+	t.Cleanup(func() { listener.Close() })
+
+	go server.serve()
+	return server
+}
+
+func (s *smtpStandIn) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpStandIn) serve() {
+	defer close(s.done)
+
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost SMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.Fields(line + " ")[0])
+		switch command {
+		case "EHLO", "HELO":
+			text.PrintfLine("250 localhost")
+		case "MAIL":
+			text.PrintfLine("250 OK")
+		case "RCPT":
+			s.recipients = append(s.recipients, strings.TrimSuffix(strings.TrimPrefix(line[len("RCPT TO:"):], "<"), ">"))
+			text.PrintfLine("250 OK")
+		case "DATA":
+			text.PrintfLine("354 Go ahead")
+			data, err := io.ReadAll(text.DotReader())
+			if err != nil {
+				return
+			}
+			s.data = string(data)
+			text.PrintfLine("250 OK")
+		case "QUIT":
+			text.PrintfLine("221 Bye")
+			return
+		default:
+			text.PrintfLine("502 Not implemented")
+		}
+	}
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	server := startSMTPStandIn(t)
+	mailer := NewSMTPMailer("127.0.0.1", server.port(), "", "", "Marie & Paul <mariage@example.com>")
+
+	email := domain.Email{
+		To:      []string{"marie@example.com", "Paul <paul@example.com>"},
+		Subject: "Nouvelle réponse : Anna Müller",
+		Body:    "Anna Müller sera présente.\nAdultes : 2",
+	}
+	if err := mailer.Send(email); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	<-server.done
+
+	if strings.Join(server.recipients, ",") != "marie@example.com,paul@example.com" {
+		t.Errorf("recipients = %v, want both addresses", server.recipients)
+	}
+
+	headerEnd := strings.Index(server.data, "\n\n")
+	if headerEnd < 0 {
+		t.Fatalf("message without body: %q", server.data)
+	}
+	header, err := textproto.NewReader(bufio.NewReader(strings.NewReader(server.data[:headerEnd+2]))).ReadMIMEHeader()
+	if err != nil {
+		t.Fatalf("ReadMIMEHeader() error = %v", err)
+	}
+	if subject := header.Get("Subject"); !strings.HasPrefix(subject, "=?utf-8?q?") {
+		t.Errorf("Subject = %q, want a UTF-8 encoded word", subject)
+	}
+	if from := header.Get("From"); !strings.Contains(from, "<mariage@example.com>") {
+		t.Errorf("From = %q", from)
+	}
+
+	body, err := io.ReadAll(quotedprintable.NewReader(strings.NewReader(server.data[headerEnd+2:])))
+	if err != nil {
+		t.Fatalf("body decoding error = %v", err)
+	}
+	if !strings.Contains(string(body), "Anna Müller sera présente.") {
+		t.Errorf("body = %q", body)
+	}
+}
+
+func TestSMTPMailerUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	mailer := NewSMTPMailer("127.0.0.1", port, "", "", "mariage@example.com")
+	if err := mailer.Send(domain.Email{To: []string{"marie@example.com"}, Subject: "Test", Body: "Test"}); err == nil {
+		t.Error("Send() error = nil, want a connection error")
+	}
+}
+
+func TestBuildMessageHeaderInjection(t *testing.T) {
+	from := &mail.Address{Address: "mariage@example.com"}
+	message, err := buildMessage(from, domain.Email{To: []string{"marie@example.com"}, Subject: "Bonjour\r\nBcc: intrus@example.com", Body: "Test"}, time.Now())
+	if err != nil {
+		t.Fatalf("buildMessage() error = %v", err)
+	}
+	if strings.Contains(string(message), "\r\nBcc:") {
+		t.Errorf("buildMessage() = %q, want the subject kept on one line", message)
+	}
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"errors"
+	"wedding-web/internal/domain"
+)
+
+var (
+	ErrCarpoolEntryNotFound = errors.New("annonce de covoiturage non trouvée")
+)
+
+// EncryptedCarpoolStorage implémente le stockage chiffré des annonces de covoiturage
+type EncryptedCarpoolStorage struct {
+	*encryptedCollection[domain.CarpoolEntry]
+}
+
+// NewEncryptedCarpoolStorage crée un nouveau storage d'annonces de covoiturage chiffré
+func NewEncryptedCarpoolStorage(filePath string, encryptionKey string) (*EncryptedCarpoolStorage, error) {
+	entries, err := newEncryptedCollection(filePath, encryptionKey, "entries", func(entry *domain.CarpoolEntry) string {
+		return entry.ID
+	}, ErrCarpoolEntryNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedCarpoolStorage{entries}, nil
+}
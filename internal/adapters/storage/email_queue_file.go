@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"errors"
+	"wedding-web/internal/domain"
+)
+
+var (
+	ErrQueuedEmailNotFound = errors.New("email en attente non trouvé")
+)
+
+// EncryptedEmailQueueStorage implémente le stockage chiffré des emails en
+// attente d'envoi (ils contiennent les réponses des invités)
+type EncryptedEmailQueueStorage struct {
+	*encryptedCollection[domain.QueuedEmail]
+}
+
+// NewEncryptedEmailQueueStorage crée un nouveau storage d'emails en attente chiffré
+func NewEncryptedEmailQueueStorage(filePath string, encryptionKey string) (*EncryptedEmailQueueStorage, error) {
+	emails, err := newEncryptedCollection(filePath, encryptionKey, "emails", func(email *domain.QueuedEmail) string {
+		return email.ID
+	}, ErrQueuedEmailNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedEmailQueueStorage{emails}, nil
+}
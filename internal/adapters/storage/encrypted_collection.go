@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"os"
+	"sync"
+)
+
+// encryptedCollection stocke une liste d'éléments identifiés dans un fichier
+// chiffré, sous la forme {"<field>": [...]}. Elle fournit les opérations
+// communes aux storages fichier (Save, FindAll, FindByID, Delete), qui
+// l'embarquent et n'ajoutent que leurs recherches spécifiques.
+type encryptedCollection[T any] struct {
+	file     *encryptedFile
+	field    string          // Clé JSON de la liste
+	id       func(*T) string // Identifiant d'un élément
+	notFound error           // Erreur retournée pour un élément inconnu
+	mu       sync.RWMutex
+}
+
+// newEncryptedCollection prépare une collection stockée dans le fichier chiffré donné
+func newEncryptedCollection[T any](filePath, encryptionKey, field string, id func(*T) string, notFound error) (*encryptedCollection[T], error) {
+	file, err := newEncryptedFile(filePath, encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptedCollection[T]{
+		file:     file,
+		field:    field,
+		id:       id,
+		notFound: notFound,
+	}, nil
+}
+
+// Save enregistre un élément (création ou mise à jour)
+func (c *encryptedCollection[T]) Save(item *T) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	items, err := c.load()
+	if err != nil {
+		return err
+	}
+
+	// Remplacer l'élément existant s'il a le même ID
+	for i, existing := range items {
+		if c.id(existing) == c.id(item) {
+			items[i] = item
+			return c.save(items)
+		}
+	}
+
+	return c.save(append(items, item))
+}
+
+// FindAll retourne tous les éléments
+func (c *encryptedCollection[T]) FindAll() ([]*T, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.load()
+}
+
+// FindByID retourne un élément par son ID
+func (c *encryptedCollection[T]) FindByID(id string) (*T, error) {
+	return c.find(func(item *T) bool {
+		return c.id(item) == id
+	})
+}
+
+// Delete supprime un élément par son ID
+func (c *encryptedCollection[T]) Delete(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	items, err := c.load()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	kept := make([]*T, 0, len(items))
+	for _, item := range items {
+		if c.id(item) == id {
+			found = true
+			continue
+		}
+		kept = append(kept, item)
+	}
+
+	if !found {
+		return c.notFound
+	}
+
+	return c.save(kept)
+}
+
+// find retourne le premier élément qui satisfait le prédicat
+func (c *encryptedCollection[T]) find(match func(*T) bool) (*T, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	items, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		if match(item) {
+			return item, nil
+		}
+	}
+
+	return nil, c.notFound
+}
+
+// load charge les éléments (liste vide si le fichier n'existe pas)
+func (c *encryptedCollection[T]) load() ([]*T, error) {
+	data := map[string][]*T{}
+	if err := c.file.load(&data); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if data[c.field] == nil {
+		return []*T{}, nil
+	}
+	return data[c.field], nil
+}
+
+// save chiffre et écrit les éléments
+func (c *encryptedCollection[T]) save(items []*T) error {
+	return c.file.save(map[string][]*T{c.field: items})
+}
@@ -111,3 +111,74 @@ func TestInvalidKey(t *testing.T) {
 		t.Errorf("Attendu ErrInvalidKeySize, obtenu: %v", err)
 	}
 }
+
+func TestEncryptedInvitationStorage(t *testing.T) {
+	key := make([]byte, 32)
+	storage, err := NewEncryptedInvitationStorage(filepath.Join(t.TempDir(), "invitations.json"), base64.StdEncoding.EncodeToString(key))
+	if err != nil {
+		t.Fatalf("Erreur création storage: %v", err)
+	}
+
+	// Fichier absent : liste vide
+	invitations, err := storage.FindAll()
+	if err != nil || len(invitations) != 0 {
+		t.Fatalf("FindAll() sans fichier = %v, %v", invitations, err)
+	}
+
+	invitation := &domain.Invitation{ID: "inv-1", Code: "abc", Name: "Famille Dupont"}
+	if err := storage.Save(invitation); err != nil {
+		t.Fatalf("Erreur Save: %v", err)
+	}
+
+	// Une invitation existante est remplacée
+	invitation.Name = "Famille Dupont-Martin"
+	if err := storage.Save(invitation); err != nil {
+		t.Fatalf("Erreur Save (mise à jour): %v", err)
+	}
+	invitations, _ = storage.FindAll()
+	if len(invitations) != 1 {
+		t.Fatalf("Attendu 1 invitation, obtenu %d", len(invitations))
+	}
+
+	found, err := storage.FindByCode("abc")
+	if err != nil || found.Name != "Famille Dupont-Martin" {
+		t.Errorf("FindByCode() = %+v, %v", found, err)
+	}
+
+	if err := storage.Delete("inv-1"); err != nil {
+		t.Fatalf("Erreur Delete: %v", err)
+	}
+	if err := storage.Delete("inv-1"); err != ErrInvitationNotFound {
+		t.Errorf("Delete() inexistant = %v, attendu %v", err, ErrInvitationNotFound)
+	}
+	if _, err := storage.FindByID("inv-1"); err != ErrInvitationNotFound {
+		t.Errorf("FindByID() inexistant = %v, attendu %v", err, ErrInvitationNotFound)
+	}
+}
+
+func TestPhotoDirectory(t *testing.T) {
+	dir, err := NewPhotoDirectory(filepath.Join(t.TempDir(), "photos"))
+	if err != nil {
+		t.Fatalf("NewPhotoDirectory() error = %v", err)
+	}
+
+	if err := dir.SaveImages("0a1b", []byte("photo"), []byte("thumb")); err != nil {
+		t.Fatalf("SaveImages() error = %v", err)
+	}
+
+	if data, err := dir.ReadImage("0a1b", true); err != nil || string(data) != "thumb" {
+		t.Errorf("ReadImage(thumbnail) = %q, %v", data, err)
+	}
+
+	// Un ID ne peut pas sortir du répertoire
+	if _, err := dir.ReadImage("../photos/0a1b", false); err != ErrPhotoNotFound {
+		t.Errorf("ReadImage(traversal) error = %v, want %v", err, ErrPhotoNotFound)
+	}
+
+	if err := dir.DeleteImages("0a1b"); err != nil {
+		t.Fatalf("DeleteImages() error = %v", err)
+	}
+	if _, err := dir.ReadImage("0a1b", false); err != ErrPhotoNotFound {
+		t.Errorf("ReadImage() after delete error = %v, want %v", err, ErrPhotoNotFound)
+	}
+}
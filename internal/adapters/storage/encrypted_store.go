@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// encryptedFile lit et écrit un document JSON chiffré avec AES-GCM.
+// Il est partagé par tous les storages fichier de l'application.
+type encryptedFile struct {
+	filePath string
+	key      []byte
+}
+
+// newEncryptedFile prépare un fichier chiffré avec une clé AES-256 encodée en base64
+func newEncryptedFile(filePath string, encryptionKey string) (*encryptedFile, error) {
+	// Décoder la clé base64
+	key, err := base64.StdEncoding.DecodeString(encryptionKey)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	// Vérifier la taille de la clé (32 bytes pour AES-256)
+	if len(key) != 32 {
+		return nil, ErrInvalidKeySize
+	}
+
+	// Créer le répertoire si nécessaire
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &encryptedFile{
+		filePath: filePath,
+		key:      key,
+	}, nil
+}
+
+// load déchiffre le fichier dans v. Retourne une erreur os.IsNotExist si le
+// fichier n'existe pas encore ; un fichier vide laisse v inchangé.
+func (f *encryptedFile) load(v interface{}) error {
+	// Lire le fichier
+	ciphertext, err := os.ReadFile(f.filePath)
+	if err != nil {
+		return err
+	}
+
+	// Si le fichier est vide, rien à charger
+	if len(ciphertext) == 0 {
+		return nil
+	}
+
+	// Déchiffrer
+	plaintext, err := f.decrypt(ciphertext)
+	if err != nil {
+		return err
+	}
+
+	// Désérialiser
+	return json.Unmarshal(plaintext, v)
+}
+
+// save chiffre v et l'écrit de manière atomique
+func (f *encryptedFile) save(v interface{}) error {
+	// Sérialiser
+	plaintext, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Chiffrer
+	ciphertext, err := f.encrypt(plaintext)
+	if err != nil {
+		return err
+	}
+
+	// Écrire dans un fichier temporaire puis renommer (atomic write)
+	tmpFile := f.filePath + ".tmp"
+	if err := os.WriteFile(tmpFile, ciphertext, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile, f.filePath)
+}
+
+// encrypt chiffre les données avec AES-GCM
+func (f *encryptedFile) encrypt(plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(f.key)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Générer un nonce aléatoire
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	// Chiffrer (le nonce est préfixé au ciphertext)
+	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
+	return ciphertext, nil
+}
+
+// decrypt déchiffre les données avec AES-GCM
+func (f *encryptedFile) decrypt(ciphertext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(f.key)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, ErrDecryptFailed
+	}
+
+	// Extraire le nonce et le ciphertext
+	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
+
+	// Déchiffrer
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrDecryptFailed
+	}
+
+	return plaintext, nil
+}
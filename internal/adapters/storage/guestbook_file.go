@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"errors"
+	"wedding-web/internal/domain"
+)
+
+var (
+	ErrGuestbookEntryNotFound = errors.New("message du livre d'or non trouvé")
+)
+
+// EncryptedGuestbookStorage implémente le stockage chiffré du livre d'or
+type EncryptedGuestbookStorage struct {
+	*encryptedCollection[domain.GuestbookEntry]
+}
+
+// NewEncryptedGuestbookStorage crée un nouveau storage du livre d'or chiffré
+func NewEncryptedGuestbookStorage(filePath string, encryptionKey string) (*EncryptedGuestbookStorage, error) {
+	entries, err := newEncryptedCollection(filePath, encryptionKey, "entries", func(entry *domain.GuestbookEntry) string {
+		return entry.ID
+	}, ErrGuestbookEntryNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedGuestbookStorage{entries}, nil
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"errors"
+	"wedding-web/internal/domain"
+)
+
+var (
+	ErrInvitationNotFound = errors.New("invitation non trouvée")
+)
+
+// EncryptedInvitationStorage implémente le stockage chiffré des invitations
+type EncryptedInvitationStorage struct {
+	*encryptedCollection[domain.Invitation]
+}
+
+// NewEncryptedInvitationStorage crée un nouveau storage d'invitations chiffré
+func NewEncryptedInvitationStorage(filePath string, encryptionKey string) (*EncryptedInvitationStorage, error) {
+	invitations, err := newEncryptedCollection(filePath, encryptionKey, "invitations", func(invitation *domain.Invitation) string {
+		return invitation.ID
+	}, ErrInvitationNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedInvitationStorage{invitations}, nil
+}
+
+// FindByCode retourne une invitation par son code personnel
+func (s *EncryptedInvitationStorage) FindByCode(code string) (*domain.Invitation, error) {
+	return s.find(func(invitation *domain.Invitation) bool {
+		return invitation.Code == code
+	})
+}
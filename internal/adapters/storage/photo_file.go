@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"wedding-web/internal/domain"
+)
+
+var (
+	ErrPhotoNotFound = errors.New("photo non trouvée")
+)
+
+// EncryptedPhotoStorage implémente le stockage chiffré des métadonnées des photos
+type EncryptedPhotoStorage struct {
+	*encryptedCollection[domain.Photo]
+}
+
+// NewEncryptedPhotoStorage crée un nouveau storage de photos chiffré
+func NewEncryptedPhotoStorage(filePath string, encryptionKey string) (*EncryptedPhotoStorage, error) {
+	photos, err := newEncryptedCollection(filePath, encryptionKey, "photos", func(photo *domain.Photo) string {
+		return photo.ID
+	}, ErrPhotoNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedPhotoStorage{photos}, nil
+}
+
+// photoIDPattern n'accepte que les IDs générés (hexadécimal) : un ID ne peut
+// pas désigner un fichier hors du répertoire
+var photoIDPattern = regexp.MustCompile(`^[a-f0-9]{1,64}$`)
+
+// PhotoDirectory range les fichiers image des photos dans un répertoire :
+// <id>.jpg pour la photo et <id>_thumb.jpg pour la miniature
+type PhotoDirectory struct {
+	dir string
+}
+
+// NewPhotoDirectory prépare le répertoire des photos (créé s'il n'existe pas)
+func NewPhotoDirectory(dir string) (*PhotoDirectory, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &PhotoDirectory{dir: dir}, nil
+}
+
+// SaveImages écrit la photo et sa miniature
+func (d *PhotoDirectory) SaveImages(id string, photo, thumbnail []byte) error {
+	if !photoIDPattern.MatchString(id) {
+		return ErrPhotoNotFound
+	}
+
+	if err := os.WriteFile(d.path(id, false), photo, 0600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(d.path(id, true), thumbnail, 0600); err != nil {
+		os.Remove(d.path(id, false))
+		return err
+	}
+	return nil
+}
+
+// ReadImage lit la photo ou sa miniature
+func (d *PhotoDirectory) ReadImage(id string, thumbnail bool) ([]byte, error) {
+	if !photoIDPattern.MatchString(id) {
+		return nil, ErrPhotoNotFound
+	}
+
+	data, err := os.ReadFile(d.path(id, thumbnail))
+	if os.IsNotExist(err) {
+		return nil, ErrPhotoNotFound
+	}
+	return data, err
+}
+
+// DeleteImages supprime la photo et sa miniature (sans erreur si absentes)
+func (d *PhotoDirectory) DeleteImages(id string) error {
+	if !photoIDPattern.MatchString(id) {
+		return ErrPhotoNotFound
+	}
+
+	for _, thumbnail := range []bool{false, true} {
+		if err := os.Remove(d.path(id, thumbnail)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// path retourne le chemin du fichier image
+func (d *PhotoDirectory) path(id string, thumbnail bool) string {
+	if thumbnail {
+		return filepath.Join(d.dir, id+"_thumb.jpg")
+	}
+	return filepath.Join(d.dir, id+".jpg")
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"errors"
+	"wedding-web/internal/domain"
+)
+
+var (
+	ErrRegistryItemNotFound = errors.New("cadeau non trouvé")
+)
+
+// EncryptedRegistryStorage implémente le stockage chiffré de la liste de mariage
+type EncryptedRegistryStorage struct {
+	*encryptedCollection[domain.RegistryItem]
+}
+
+// NewEncryptedRegistryStorage crée un nouveau storage de liste de mariage chiffré
+func NewEncryptedRegistryStorage(filePath string, encryptionKey string) (*EncryptedRegistryStorage, error) {
+	items, err := newEncryptedCollection(filePath, encryptionKey, "items", func(item *domain.RegistryItem) string {
+		return item.ID
+	}, ErrRegistryItemNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedRegistryStorage{items}, nil
+}
@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"errors"
+	"wedding-web/internal/domain"
+)
+
+var (
+	ErrReminderCampaignNotFound = errors.New("campagne de relance non trouvée")
+)
+
+// EncryptedReminderCampaignStorage implémente le stockage chiffré des
+// campagnes de relance et des foyers déjà relancés
+type EncryptedReminderCampaignStorage struct {
+	*encryptedCollection[domain.ReminderCampaign]
+}
+
+// NewEncryptedReminderCampaignStorage crée un nouveau storage de campagnes de relance chiffré
+func NewEncryptedReminderCampaignStorage(filePath string, encryptionKey string) (*EncryptedReminderCampaignStorage, error) {
+	campaigns, err := newEncryptedCollection(filePath, encryptionKey, "campaigns", func(campaign *domain.ReminderCampaign) string {
+		return campaign.ID
+	}, ErrReminderCampaignNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedReminderCampaignStorage{campaigns}, nil
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"errors"
+	"wedding-web/internal/domain"
+)
+
+var (
+	ErrTableNotFound = errors.New("table non trouvée")
+)
+
+// EncryptedSeatingStorage implémente le stockage chiffré du plan de table
+type EncryptedSeatingStorage struct {
+	*encryptedCollection[domain.Table]
+}
+
+// NewEncryptedSeatingStorage crée un nouveau storage de plan de table chiffré
+func NewEncryptedSeatingStorage(filePath string, encryptionKey string) (*EncryptedSeatingStorage, error) {
+	tables, err := newEncryptedCollection(filePath, encryptionKey, "tables", func(table *domain.Table) string {
+		return table.ID
+	}, ErrTableNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedSeatingStorage{tables}, nil
+}
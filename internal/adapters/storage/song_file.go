@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"errors"
+	"wedding-web/internal/domain"
+)
+
+var (
+	ErrSongNotFound = errors.New("suggestion musicale non trouvée")
+)
+
+// EncryptedSongStorage implémente le stockage chiffré des suggestions musicales
+type EncryptedSongStorage struct {
+	*encryptedCollection[domain.SongSuggestion]
+}
+
+// NewEncryptedSongStorage crée un nouveau storage de suggestions musicales chiffré
+func NewEncryptedSongStorage(filePath string, encryptionKey string) (*EncryptedSongStorage, error) {
+	songs, err := newEncryptedCollection(filePath, encryptionKey, "songs", func(song *domain.SongSuggestion) string {
+		return song.ID
+	}, ErrSongNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedSongStorage{songs}, nil
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"errors"
+	"wedding-web/internal/domain"
+)
+
+var (
+	ErrThankYouNotFound = errors.New("remerciement non trouvé")
+)
+
+// EncryptedThankYouStorage implémente le stockage chiffré du suivi des remerciements
+type EncryptedThankYouStorage struct {
+	*encryptedCollection[domain.ThankYou]
+}
+
+// NewEncryptedThankYouStorage crée un nouveau storage de remerciements chiffré
+func NewEncryptedThankYouStorage(filePath string, encryptionKey string) (*EncryptedThankYouStorage, error) {
+	thanks, err := newEncryptedCollection(filePath, encryptionKey, "thank_yous", func(thanks *domain.ThankYou) string {
+		return thanks.ID
+	}, ErrThankYouNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedThankYouStorage{thanks}, nil
+}
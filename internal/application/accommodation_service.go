@@ -0,0 +1,112 @@
+package application
+
+import (
+	"errors"
+	"io/fs"
+	"time"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/domain/ports"
+	"wedding-web/internal/i18n"
+)
+
+// NightDemand résume les demandes d'hébergement pour une nuit
+type NightDemand struct {
+	Night      time.Time
+	Households int
+	Guests     int
+}
+
+// AccommodationDemand résume les demandes d'hébergement des invités
+type AccommodationDemand struct {
+	Households int // Foyers cherchant au moins une nuit
+	Guests     int
+	Nights     []NightDemand
+}
+
+// PeakHouseholds retourne le nombre maximal de foyers à loger la même nuit
+func (d *AccommodationDemand) PeakHouseholds() int {
+	peak := 0
+	for _, night := range d.Nights {
+		peak = max(peak, night.Households)
+	}
+	return peak
+}
+
+// AccommodationService gère l'annuaire des hébergements et les demandes des invités
+type AccommodationService struct {
+	source      ports.AccommodationSource
+	rsvpService *RSVPService
+}
+
+// NewAccommodationService crée un nouveau service d'hébergement
+func NewAccommodationService(source ports.AccommodationSource, rsvpService *RSVPService) *AccommodationService {
+	return &AccommodationService{
+		source:      source,
+		rsvpService: rsvpService,
+	}
+}
+
+// ListAccommodations retourne l'annuaire des hébergements dans la langue donnée.
+// S'il n'est pas rédigé dans cette langue, la version française est utilisée.
+// L'annuaire est facultatif : sans fichier, la liste est vide.
+func (s *AccommodationService) ListAccommodations(lang i18n.Lang) ([]domain.Accommodation, error) {
+	accommodations, err := s.source.LoadAccommodations(string(lang))
+	if err != nil && lang != i18n.Default {
+		accommodations, err = s.source.LoadAccommodations(string(i18n.Default))
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Join(ErrContentUnavailable, err)
+	}
+	return accommodations, nil
+}
+
+// RoomsBlocked retourne le nombre total de chambres réservées pour le mariage
+func (s *AccommodationService) RoomsBlocked(accommodations []domain.Accommodation) int {
+	total := 0
+	for _, accommodation := range accommodations {
+		total += accommodation.RoomsBlocked
+	}
+	return total
+}
+
+// Nights retourne les nuits proposées aux invités
+func (s *AccommodationService) Nights() []time.Time {
+	return s.rsvpService.AccommodationNights()
+}
+
+// Demand agrège les besoins d'hébergement exprimés dans les RSVP
+func (s *AccommodationService) Demand() (*AccommodationDemand, error) {
+	rsvps, err := s.rsvpService.ListRSVPs()
+	if err != nil {
+		return nil, err
+	}
+	return accommodationDemand(rsvps, s.Nights()), nil
+}
+
+// accommodationDemand agrège les besoins d'hébergement par nuit
+func accommodationDemand(rsvps []*domain.RSVP, nights []time.Time) *AccommodationDemand {
+	demand := &AccommodationDemand{Nights: make([]NightDemand, len(nights))}
+	for i, night := range nights {
+		demand.Nights[i].Night = night
+	}
+
+	for _, rsvp := range rsvps {
+		if !rsvp.WillAttend || !rsvp.NeedsAccommodation() {
+			continue
+		}
+		demand.Households++
+		demand.Guests += rsvp.TotalGuests()
+
+		for i, night := range nights {
+			if rsvp.NeedsAccommodationOn(night) {
+				demand.Nights[i].Households++
+				demand.Nights[i].Guests += rsvp.TotalGuests()
+			}
+		}
+	}
+
+	return demand
+}
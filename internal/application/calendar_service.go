@@ -2,51 +2,234 @@ package application
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 	"wedding-web/internal/domain"
+	"wedding-web/internal/i18n"
 )
 
+var (
+	ErrEventNotSchedulable = errors.New("événement sans horaire")
+)
+
+// feedRefreshInterval indique aux clients abonnés la fréquence de rafraîchissement
+const feedRefreshInterval = "PT12H"
+
 // CalendarService génère des fichiers ICS
-type CalendarService struct{}
+type CalendarService struct {
+	defaultReminders []time.Duration // Rappels appliqués aux événements sans rappels propres
+}
 
 // NewCalendarService crée un nouveau service calendar
-func NewCalendarService() *CalendarService {
-	return &CalendarService{}
+func NewCalendarService(defaultReminders []time.Duration) *CalendarService {
+	return &CalendarService{
+		defaultReminders: defaultReminders,
+	}
+}
+
+// LastModified retourne la date de dernière modification du calendrier,
+// issue du planning : elle ne change pas d'un redémarrage à l'autre
+func (s *CalendarService) LastModified(planning *domain.Planning) time.Time {
+	return planning.UpdatedAt.UTC().Truncate(time.Second)
+}
+
+// GenerateICS génère un fichier .ics pour le planning, rappels rédigés dans la langue donnée
+func (s *CalendarService) GenerateICS(planning *domain.Planning, lang string) ([]byte, error) {
+	var buf bytes.Buffer
+	t := i18n.NewTranslations(i18n.Lang(lang))
+
+	writeCalendarHeader(&buf, t.T("calendar.name"))
+
+	// Ajouter chaque événement
+	for _, event := range planning.Events {
+		s.writeEvent(&buf, event, planning.UpdatedAt, t)
+	}
+
+	buf.WriteString("END:VCALENDAR\r\n")
+
+	return buf.Bytes(), nil
 }
 
-// GenerateICS génère un fichier .ics pour le planning
-func (s *CalendarService) GenerateICS(planning *domain.Planning) ([]byte, error) {
+// GenerateFeed génère le flux d'abonnement (webcal) du planning.
+// Avec une invitation, le flux ne contient que les événements auxquels
+// le foyer est convié, ainsi que ses rappels personnels.
+func (s *CalendarService) GenerateFeed(planning *domain.Planning, invitation *domain.Invitation, lang string) ([]byte, error) {
 	var buf bytes.Buffer
+	t := i18n.NewTranslations(i18n.Lang(lang))
 
-	// En-tête ICS
+	calendarName := t.T("calendar.name")
+	if invitation != nil {
+		calendarName = t.T("calendar.name_personal", "name", invitation.Name)
+	}
+
+	writeCalendarHeader(&buf, calendarName)
+	buf.WriteString(fmt.Sprintf("REFRESH-INTERVAL;VALUE=DURATION:%s\r\n", feedRefreshInterval))
+	buf.WriteString(fmt.Sprintf("X-PUBLISHED-TTL:%s\r\n", feedRefreshInterval))
+
+	for _, event := range planning.VisibleTo(invitation).Events {
+		s.writeEvent(&buf, event, planning.UpdatedAt, t)
+	}
+
+	if invitation != nil {
+		s.writePersonalReminders(&buf, planning, invitation, t)
+	}
+
+	buf.WriteString("END:VCALENDAR\r\n")
+
+	return buf.Bytes(), nil
+}
+
+// GenerateEventICS génère un fichier .ics ne contenant qu'un seul événement du planning
+func (s *CalendarService) GenerateEventICS(planning *domain.Planning, event domain.PlanningEvent, lang string) ([]byte, error) {
+	if event.StartTime.IsZero() {
+		return nil, ErrEventNotSchedulable
+	}
+
+	var buf bytes.Buffer
+	t := i18n.NewTranslations(i18n.Lang(lang))
+
+	writeCalendarHeader(&buf, event.In(t.Lang()).Title)
+	s.writeEvent(&buf, event, planning.UpdatedAt, t)
+	buf.WriteString("END:VCALENDAR\r\n")
+
+	return buf.Bytes(), nil
+}
+
+// GoogleCalendarURL retourne le lien "ajouter à Google Agenda" d'un événement
+func (s *CalendarService) GoogleCalendarURL(event domain.PlanningEvent) string {
+	params := url.Values{}
+	params.Set("action", "TEMPLATE")
+	params.Set("text", event.Title)
+	params.Set("dates", formatICSDate(event.StartTime)+"/"+formatICSDate(event.EndTime))
+	params.Set("details", eventDescription(event))
+	params.Set("location", eventLocation(event))
+	return "https://calendar.google.com/calendar/render?" + encodeQuery(params)
+}
+
+// OutlookCalendarURL retourne le lien "ajouter à Outlook.com" d'un événement
+func (s *CalendarService) OutlookCalendarURL(event domain.PlanningEvent) string {
+	params := url.Values{}
+	params.Set("path", "/calendar/action/compose")
+	params.Set("rru", "addevent")
+	params.Set("subject", event.Title)
+	params.Set("startdt", event.StartTime.UTC().Format(time.RFC3339))
+	params.Set("enddt", event.EndTime.UTC().Format(time.RFC3339))
+	params.Set("body", eventDescription(event))
+	params.Set("location", eventLocation(event))
+	return "https://outlook.live.com/calendar/0/deeplink/compose?" + encodeQuery(params)
+}
+
+// encodeQuery encode les paramètres en utilisant %20 pour les espaces :
+// Outlook.com n'interprète pas "+" comme un espace dans ses liens profonds
+func encodeQuery(params url.Values) string {
+	return strings.ReplaceAll(params.Encode(), "+", "%20")
+}
+
+// writeCalendarHeader écrit l'en-tête ICS
+func writeCalendarHeader(buf *bytes.Buffer, name string) {
 	buf.WriteString("BEGIN:VCALENDAR\r\n")
 	buf.WriteString("VERSION:2.0\r\n")
 	buf.WriteString("PRODID:-//Wedding Web//FR\r\n")
 	buf.WriteString("CALSCALE:GREGORIAN\r\n")
 	buf.WriteString("METHOD:PUBLISH\r\n")
-	buf.WriteString("X-WR-CALNAME:Mariage\r\n")
+	buf.WriteString(fmt.Sprintf("X-WR-CALNAME:%s\r\n", escapeICS(name)))
 	buf.WriteString("X-WR-TIMEZONE:Europe/Paris\r\n")
+}
 
-	// Ajouter chaque événement
-	for _, event := range planning.Events {
-		buf.WriteString("BEGIN:VEVENT\r\n")
-		buf.WriteString(fmt.Sprintf("UID:%s@wedding-web\r\n", generateEventUID(event)))
-		buf.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", formatICSDate(time.Now())))
-		buf.WriteString(fmt.Sprintf("DTSTART:%s\r\n", formatICSDate(event.StartTime)))
-		buf.WriteString(fmt.Sprintf("DTEND:%s\r\n", formatICSDate(event.EndTime)))
-		buf.WriteString(fmt.Sprintf("SUMMARY:%s\r\n", escapeICS(event.Title)))
-		buf.WriteString(fmt.Sprintf("DESCRIPTION:%s\r\n", escapeICS(event.Description)))
-		buf.WriteString(fmt.Sprintf("LOCATION:%s\\, %s\r\n", escapeICS(event.Location), escapeICS(event.Address)))
-		buf.WriteString("STATUS:CONFIRMED\r\n")
-		buf.WriteString("SEQUENCE:0\r\n")
-		buf.WriteString("END:VEVENT\r\n")
+// writeEvent écrit un VEVENT horodaté par stamp (DTSTAMP), rédigé dans la
+// langue des traductions. Les événements sans horaire ne sont pas exportés.
+func (s *CalendarService) writeEvent(buf *bytes.Buffer, event domain.PlanningEvent, stamp time.Time, t *i18n.Translations) {
+	if event.StartTime.IsZero() {
+		return
 	}
 
-	buf.WriteString("END:VCALENDAR\r\n")
+	// L'UID reste celui de l'événement en français : changer de langue ne
+	// doit pas dupliquer l'événement dans les calendriers abonnés
+	uid := generateEventUID(event)
+	event = event.In(t.Lang())
 
-	return buf.Bytes(), nil
+	buf.WriteString("BEGIN:VEVENT\r\n")
+	buf.WriteString(fmt.Sprintf("UID:%s@wedding-web\r\n", uid))
+	buf.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", formatICSDate(stamp)))
+	buf.WriteString(fmt.Sprintf("DTSTART:%s\r\n", formatICSDate(event.StartTime)))
+	buf.WriteString(fmt.Sprintf("DTEND:%s\r\n", formatICSDate(event.EndTime)))
+	buf.WriteString(fmt.Sprintf("SUMMARY:%s\r\n", escapeICS(event.Title)))
+	buf.WriteString(fmt.Sprintf("DESCRIPTION:%s\r\n", escapeICS(eventDescription(event))))
+	buf.WriteString(fmt.Sprintf("LOCATION:%s\r\n", escapeICS(eventLocation(event))))
+	if event.Venue != nil && event.Venue.HasCoordinates() {
+		buf.WriteString(fmt.Sprintf("GEO:%.6f;%.6f\r\n", event.Venue.Latitude, event.Venue.Longitude))
+	}
+	buf.WriteString("STATUS:CONFIRMED\r\n")
+	buf.WriteString("SEQUENCE:0\r\n")
+	s.writeAlarms(buf, event, t)
+	buf.WriteString("END:VEVENT\r\n")
+}
+
+// writeAlarms écrit un VALARM par rappel de l'événement
+func (s *CalendarService) writeAlarms(buf *bytes.Buffer, event domain.PlanningEvent, t *i18n.Translations) {
+	reminders := event.Reminders
+	if reminders == nil {
+		reminders = s.defaultReminders
+	}
+
+	description := t.T("calendar.reminder", "event", event.Title)
+	for _, before := range reminders {
+		buf.WriteString("BEGIN:VALARM\r\n")
+		buf.WriteString("ACTION:DISPLAY\r\n")
+		buf.WriteString(fmt.Sprintf("TRIGGER:-%s\r\n", formatICSDuration(before)))
+		buf.WriteString(fmt.Sprintf("DESCRIPTION:%s\r\n", escapeICS(description)))
+		buf.WriteString("END:VALARM\r\n")
+	}
+}
+
+// writePersonalReminders écrit les rappels propres à une invitation
+func (s *CalendarService) writePersonalReminders(buf *bytes.Buffer, planning *domain.Planning, invitation *domain.Invitation, t *i18n.Translations) {
+	if planning.RSVPDeadline.IsZero() {
+		return
+	}
+
+	// Rappel de la date limite de réponse (événement sur la journée entière)
+	deadline := planning.RSVPDeadline
+	buf.WriteString("BEGIN:VEVENT\r\n")
+	buf.WriteString(fmt.Sprintf("UID:rsvp-deadline-%s@wedding-web\r\n", invitation.ID))
+	buf.WriteString(fmt.Sprintf("DTSTAMP:%s\r\n", formatICSDate(planning.UpdatedAt)))
+	buf.WriteString(fmt.Sprintf("DTSTART;VALUE=DATE:%s\r\n", deadline.Format("20060102")))
+	buf.WriteString(fmt.Sprintf("DTEND;VALUE=DATE:%s\r\n", deadline.AddDate(0, 0, 1).Format("20060102")))
+	buf.WriteString(fmt.Sprintf("SUMMARY:%s\r\n", escapeICS(t.T("calendar.rsvp_deadline"))))
+	buf.WriteString(fmt.Sprintf("DESCRIPTION:%s\r\n", escapeICS(
+		t.T("calendar.rsvp_deadline_desc", "name", invitation.Name))))
+	buf.WriteString("TRANSP:TRANSPARENT\r\n")
+	buf.WriteString("END:VEVENT\r\n")
+}
+
+// eventLocation retourne le lieu complet d'un événement ("Lieu, Adresse")
+func eventLocation(event domain.PlanningEvent) string {
+	venue := event.Venue
+	if venue == nil {
+		return ""
+	}
+	if venue.Address == "" {
+		return venue.Name
+	}
+	if venue.Name == "" {
+		return venue.Address
+	}
+	return venue.Name + ", " + venue.Address
+}
+
+// eventDescription retourne la description d'un événement, complétée des
+// indications de stationnement du lieu
+func eventDescription(event domain.PlanningEvent) string {
+	if event.Venue == nil || event.Venue.ParkingNotes == "" {
+		return event.Description
+	}
+	if event.Description == "" {
+		return event.Venue.ParkingNotes
+	}
+	return event.Description + "\n" + event.Venue.ParkingNotes
 }
 
 // formatICSDate formate une date pour ICS (format: 20260711T143000Z)
@@ -54,6 +237,27 @@ func formatICSDate(t time.Time) string {
 	return t.UTC().Format("20060102T150405Z")
 }
 
+// formatICSDuration formate une durée pour ICS (format: P1D, PT2H, PT1H30M)
+func formatICSDuration(d time.Duration) string {
+	if d > 0 && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("P%dD", d/(24*time.Hour))
+	}
+
+	d = d.Round(time.Minute)
+	hours := d / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+
+	var sb strings.Builder
+	sb.WriteString("PT")
+	if hours > 0 {
+		sb.WriteString(fmt.Sprintf("%dH", hours))
+	}
+	if minutes > 0 || hours == 0 {
+		sb.WriteString(fmt.Sprintf("%dM", minutes))
+	}
+	return sb.String()
+}
+
 // escapeICS échappe les caractères spéciaux pour ICS
 func escapeICS(s string) string {
 	s = strings.ReplaceAll(s, "\\", "\\\\")
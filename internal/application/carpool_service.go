@@ -0,0 +1,224 @@
+package application
+
+import (
+	"errors"
+	"sort"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/domain/ports"
+)
+
+var (
+	ErrInvitationRequired   = errors.New("invitation requise")
+	ErrUnknownCarpoolLeg    = errors.New("trajet inconnu")
+	ErrCarpoolEntryNotFound = errors.New("annonce de covoiturage introuvable")
+)
+
+// CarpoolMatch associe une offre de places aux passagers qui y trouvent place
+type CarpoolMatch struct {
+	Offer      *domain.CarpoolEntry
+	Passengers []*domain.CarpoolEntry
+	SeatsLeft  int
+}
+
+// CarpoolBoardLeg regroupe les annonces d'un trajet et leur appariement
+type CarpoolBoardLeg struct {
+	Leg            domain.CarpoolLeg
+	Matches        []CarpoolMatch
+	Unmatched      []*domain.CarpoolEntry // Demandes sans place disponible
+	SeatsOffered   int
+	SeatsRequested int
+}
+
+// ShuttleSeats retourne le nombre de places demandées restées sans solution,
+// à couvrir par une navette
+func (l CarpoolBoardLeg) ShuttleSeats() int {
+	seats := 0
+	for _, request := range l.Unmatched {
+		seats += request.Seats
+	}
+	return seats
+}
+
+// CarpoolService gère les offres et demandes de covoiturage entre les lieux
+type CarpoolService struct {
+	storage         ports.CarpoolStorage
+	planningService *PlanningService
+	rsvpService     *RSVPService
+}
+
+// NewCarpoolService crée un nouveau service de covoiturage
+func NewCarpoolService(storage ports.CarpoolStorage, planningService *PlanningService, rsvpService *RSVPService) *CarpoolService {
+	return &CarpoolService{
+		storage:         storage,
+		planningService: planningService,
+		rsvpService:     rsvpService,
+	}
+}
+
+// Legs retourne les trajets proposés à l'invitation (selon les événements auxquels elle est conviée)
+func (s *CarpoolService) Legs(invitation *domain.Invitation) []domain.CarpoolLeg {
+	return s.planningService.GetPlanningFor(invitation).CarpoolLegs()
+}
+
+// CanPost indique si le foyer invité peut publier une annonce :
+// il doit avoir confirmé sa présence
+func (s *CarpoolService) CanPost(invitation *domain.Invitation) (bool, error) {
+	return s.rsvpService.IsAttending(invitation)
+}
+
+// PostEntry publie une offre ou une demande de places pour le foyer invité
+func (s *CarpoolService) PostEntry(invitation *domain.Invitation, kind, legID, name, contact string, seats int, notes string) (*domain.CarpoolEntry, error) {
+	if invitation == nil {
+		return nil, ErrInvitationRequired
+	}
+
+	attending, err := s.CanPost(invitation)
+	if err != nil {
+		return nil, err
+	}
+	if !attending {
+		return nil, ErrNotAttending
+	}
+
+	entry, err := domain.NewCarpoolEntry(domain.CarpoolKind(kind), legID, name, contact, seats, notes)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := findLeg(s.Legs(invitation), entry.LegID); !ok {
+		return nil, ErrUnknownCarpoolLeg
+	}
+
+	entry.ID = generateID()
+	entry.InvitationID = invitation.ID
+
+	if err := s.storage.Save(entry); err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	return entry, nil
+}
+
+// EntriesOf retourne les annonces publiées par le foyer invité
+func (s *CarpoolService) EntriesOf(invitation *domain.Invitation) ([]*domain.CarpoolEntry, error) {
+	entries, err := s.storage.FindAll()
+	if err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	var own []*domain.CarpoolEntry
+	for _, entry := range entries {
+		if invitation != nil && entry.InvitationID == invitation.ID {
+			own = append(own, entry)
+		}
+	}
+	return own, nil
+}
+
+// WithdrawEntry retire une annonce publiée par le foyer invité
+func (s *CarpoolService) WithdrawEntry(invitation *domain.Invitation, id string) error {
+	if invitation == nil {
+		return ErrInvitationRequired
+	}
+
+	entry, err := s.storage.FindByID(id)
+	if err != nil || entry.InvitationID != invitation.ID {
+		return ErrCarpoolEntryNotFound
+	}
+
+	return s.DeleteEntry(id)
+}
+
+// DeleteEntry supprime une annonce par son ID (administration)
+func (s *CarpoolService) DeleteEntry(id string) error {
+	if err := s.storage.Delete(id); err != nil {
+		return ErrStorageFailure
+	}
+	return nil
+}
+
+// Board retourne le tableau des trajets visibles par l'invitation avec l'appariement
+// des offres et des demandes
+func (s *CarpoolService) Board(invitation *domain.Invitation) ([]CarpoolBoardLeg, error) {
+	return s.board(s.Legs(invitation))
+}
+
+// Overview retourne le tableau de tous les trajets (administration)
+func (s *CarpoolService) Overview() ([]CarpoolBoardLeg, error) {
+	return s.board(s.planningService.GetPlanning().CarpoolLegs())
+}
+
+// board regroupe les annonces par trajet puis les apparie
+func (s *CarpoolService) board(legs []domain.CarpoolLeg) ([]CarpoolBoardLeg, error) {
+	entries, err := s.storage.FindAll()
+	if err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	// Traiter les annonces dans leur ordre de publication
+	sorted := make([]*domain.CarpoolEntry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	board := make([]CarpoolBoardLeg, 0, len(legs))
+	for _, leg := range legs {
+		var offers, requests []*domain.CarpoolEntry
+		for _, entry := range sorted {
+			if entry.LegID != leg.ID {
+				continue
+			}
+			if entry.IsOffer() {
+				offers = append(offers, entry)
+			} else {
+				requests = append(requests, entry)
+			}
+		}
+		board = append(board, matchCarpool(leg, offers, requests))
+	}
+
+	return board, nil
+}
+
+// matchCarpool place chaque demande (sans la scinder) dans la première offre
+// ayant assez de places libres, selon l'ordre de publication
+func matchCarpool(leg domain.CarpoolLeg, offers, requests []*domain.CarpoolEntry) CarpoolBoardLeg {
+	result := CarpoolBoardLeg{Leg: leg}
+
+	for _, offer := range offers {
+		result.Matches = append(result.Matches, CarpoolMatch{Offer: offer, SeatsLeft: offer.Seats})
+		result.SeatsOffered += offer.Seats
+	}
+
+	for _, request := range requests {
+		result.SeatsRequested += request.Seats
+
+		matched := false
+		for i := range result.Matches {
+			match := &result.Matches[i]
+			// Un foyer ne se transporte pas lui-même
+			if match.SeatsLeft >= request.Seats && match.Offer.InvitationID != request.InvitationID {
+				match.Passengers = append(match.Passengers, request)
+				match.SeatsLeft -= request.Seats
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			result.Unmatched = append(result.Unmatched, request)
+		}
+	}
+
+	return result
+}
+
+// findLeg retourne le trajet correspondant à l'identifiant
+func findLeg(legs []domain.CarpoolLeg, id string) (domain.CarpoolLeg, bool) {
+	for _, leg := range legs {
+		if leg.ID == id {
+			return leg, true
+		}
+	}
+	return domain.CarpoolLeg{}, false
+}
@@ -0,0 +1,71 @@
+package application
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/i18n"
+)
+
+// emailTemplates sont les emails rédigés (fichiers *.txt) : la première
+// ligne « Subject: ... » donne le sujet, la suite le corps du message. Les
+// emails destinés aux invités existent par langue (<nom>.<lang>.txt).
+type emailTemplates struct {
+	tmpl *template.Template
+}
+
+// loadEmailTemplates charge les templates d'emails du répertoire donné
+func loadEmailTemplates(dir string) (*emailTemplates, error) {
+	funcs := template.FuncMap{
+		"date": func(lang string, date time.Time) string {
+			return i18n.NewTranslations(i18n.Lang(lang)).FormatDate(date)
+		},
+		"longDate": func(lang string, date time.Time) string {
+			return i18n.NewTranslations(i18n.Lang(lang)).FormatLongDate(date)
+		},
+		"dateTime": func(lang string, date time.Time) string {
+			return i18n.NewTranslations(i18n.Lang(lang)).FormatDateTime(date)
+		},
+		"join":  strings.Join,
+		"upper": strings.ToUpper,
+	}
+
+	tmpl, err := template.New("").Funcs(funcs).ParseGlob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("templates d'emails: %w", err)
+	}
+	return &emailTemplates{tmpl: tmpl}, nil
+}
+
+// localized retourne le nom du template dans la langue donnée
+// (<name>.<lang>.txt), à défaut dans la langue par défaut
+func (t *emailTemplates) localized(name string, lang i18n.Lang) string {
+	if localized := name + "." + string(lang) + ".txt"; t.tmpl.Lookup(localized) != nil {
+		return localized
+	}
+	return name + "." + string(i18n.Default) + ".txt"
+}
+
+// render rédige l'email du template name pour les destinataires donnés
+func (t *emailTemplates) render(name string, to []string, data interface{}) (domain.Email, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return domain.Email{}, err
+	}
+
+	header, body, _ := strings.Cut(buf.String(), "\n")
+	subject, ok := strings.CutPrefix(header, "Subject:")
+	if !ok {
+		return domain.Email{}, fmt.Errorf("template %s: première ligne « Subject: » manquante", name)
+	}
+
+	return domain.Email{
+		To:      to,
+		Subject: strings.TrimSpace(subject),
+		Body:    strings.TrimSpace(body) + "\n",
+	}, nil
+}
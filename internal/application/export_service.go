@@ -1,21 +1,29 @@
 package application
 
 import (
+	"bufio"
+	"encoding/csv"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 	"time"
+	"wedding-web/internal/domain"
 
 	"github.com/xuri/excelize/v2"
 )
 
 // ExportService gère l'export des données
 type ExportService struct {
-	rsvpService *RSVPService
+	rsvpService    *RSVPService
+	seatingService *SeatingService
 }
 
 // NewExportService crée un nouveau service d'export
-func NewExportService(rsvpService *RSVPService) *ExportService {
+func NewExportService(rsvpService *RSVPService, seatingService *SeatingService) *ExportService {
 	return &ExportService{
-		rsvpService: rsvpService,
+		rsvpService:    rsvpService,
+		seatingService: seatingService,
 	}
 }
 
@@ -38,7 +46,7 @@ func (s *ExportService) ExportRSVPsToExcel() (*excelize.File, error) {
 	}
 
 	// Définir les en-têtes
-	headers := []string{"Prénom", "Nom", "Statut", "Adultes", "Enfants", "Total", "Allergies/Régimes", "Message", "Date"}
+	headers := []string{"Prénom", "Nom", "Statut", "Adultes", "Enfants", "Total", "Allergies/Régimes", "Message", "Date", "Langue"}
 	for i, header := range headers {
 		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
 		f.SetCellValue(sheetName, cell, header)
@@ -54,7 +62,7 @@ func (s *ExportService) ExportRSVPsToExcel() (*excelize.File, error) {
 		},
 	})
 	if err == nil {
-		f.SetCellStyle(sheetName, "A1", "I1", headerStyle)
+		f.SetCellStyle(sheetName, "A1", "J1", headerStyle)
 	}
 
 	// Ajouter les données
@@ -76,6 +84,7 @@ func (s *ExportService) ExportRSVPsToExcel() (*excelize.File, error) {
 		f.SetCellValue(sheetName, fmt.Sprintf("G%d", row), rsvp.Allergies)
 		f.SetCellValue(sheetName, fmt.Sprintf("H%d", row), rsvp.Message)
 		f.SetCellValue(sheetName, fmt.Sprintf("I%d", row), rsvp.SubmittedAt.Format("02/01/2006 15:04"))
+		f.SetCellValue(sheetName, fmt.Sprintf("J%d", row), strings.ToUpper(rsvp.Lang))
 	}
 
 	// Ajouter une ligne de résumé
@@ -93,7 +102,7 @@ func (s *ExportService) ExportRSVPsToExcel() (*excelize.File, error) {
 		Fill: excelize.Fill{Type: "pattern", Color: []string{"#D4E4F7"}, Pattern: 1},
 	})
 	if err == nil {
-		f.SetCellStyle(sheetName, fmt.Sprintf("A%d", summaryRow), fmt.Sprintf("I%d", summaryRow), summaryStyle)
+		f.SetCellStyle(sheetName, fmt.Sprintf("A%d", summaryRow), fmt.Sprintf("J%d", summaryRow), summaryStyle)
 	}
 
 	// Ajuster la largeur des colonnes
@@ -103,9 +112,20 @@ func (s *ExportService) ExportRSVPsToExcel() (*excelize.File, error) {
 	f.SetColWidth(sheetName, "G", "G", 30)
 	f.SetColWidth(sheetName, "H", "H", 40)
 	f.SetColWidth(sheetName, "I", "I", 18)
+	f.SetColWidth(sheetName, "J", "J", 10)
 
 	// Activer les filtres
-	f.AutoFilter(sheetName, "A1:I1", []excelize.AutoFilterOptions{})
+	f.AutoFilter(sheetName, "A1:J1", []excelize.AutoFilterOptions{})
+
+	// Feuille des besoins d'hébergement
+	if err := s.writeAccommodationSheet(f, rsvps); err != nil {
+		return nil, err
+	}
+
+	// Feuille du plan de table
+	if err := s.writeSeatingSheet(f); err != nil {
+		return nil, err
+	}
 
 	// Définir la feuille active
 	f.SetActiveSheet(index)
@@ -116,6 +136,433 @@ func (s *ExportService) ExportRSVPsToExcel() (*excelize.File, error) {
 	return f, nil
 }
 
+// writeAccommodationSheet ajoute la feuille des foyers cherchant un hébergement,
+// avec une colonne par nuit proposée et les totaux par nuit
+func (s *ExportService) writeAccommodationSheet(f *excelize.File, rsvps []*domain.RSVP) error {
+	sheetName := "Hébergement"
+	if _, err := f.NewSheet(sheetName); err != nil {
+		return err
+	}
+
+	nights := s.rsvpService.AccommodationNights()
+
+	// En-têtes : foyer, personnes, puis une colonne par nuit
+	headers := []string{"Prénom", "Nom", "Personnes"}
+	for _, night := range nights {
+		headers = append(headers, "Nuit du "+night.Format("02/01/2006"))
+	}
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellValue(sheetName, cell, header)
+	}
+	lastColumn, _ := excelize.ColumnNumberToName(len(headers))
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Size: 12},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#E8E8E8"}, Pattern: 1},
+		Alignment: &excelize.Alignment{
+			Horizontal: "center",
+			Vertical:   "center",
+		},
+	})
+	if err == nil {
+		f.SetCellStyle(sheetName, "A1", lastColumn+"1", headerStyle)
+	}
+
+	// Un foyer par ligne, une croix par nuit demandée
+	row := 2
+	for _, rsvp := range rsvps {
+		if !rsvp.WillAttend || !rsvp.NeedsAccommodation() {
+			continue
+		}
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), rsvp.FirstName)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), rsvp.LastName)
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), rsvp.TotalGuests())
+		for i, night := range nights {
+			if rsvp.NeedsAccommodationOn(night) {
+				cell, _ := excelize.CoordinatesToCellName(i+4, row)
+				f.SetCellValue(sheetName, cell, "X")
+			}
+		}
+		row++
+	}
+
+	// Totaux par nuit : foyers puis personnes
+	demand := accommodationDemand(rsvps, nights)
+	householdsRow, guestsRow := row+1, row+2
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", householdsRow), "FOYERS")
+	f.SetCellValue(sheetName, fmt.Sprintf("A%d", guestsRow), "PERSONNES")
+	f.SetCellValue(sheetName, fmt.Sprintf("C%d", householdsRow), demand.Households)
+	f.SetCellValue(sheetName, fmt.Sprintf("C%d", guestsRow), demand.Guests)
+	for i, night := range demand.Nights {
+		cell, _ := excelize.CoordinatesToCellName(i+4, householdsRow)
+		f.SetCellValue(sheetName, cell, night.Households)
+		cell, _ = excelize.CoordinatesToCellName(i+4, guestsRow)
+		f.SetCellValue(sheetName, cell, night.Guests)
+	}
+
+	summaryStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Size: 11},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#D4E4F7"}, Pattern: 1},
+	})
+	if err == nil {
+		f.SetCellStyle(sheetName, fmt.Sprintf("A%d", householdsRow), fmt.Sprintf("%s%d", lastColumn, guestsRow), summaryStyle)
+	}
+
+	f.SetColWidth(sheetName, "A", "B", 15)
+	f.SetColWidth(sheetName, "C", lastColumn, 18)
+
+	return nil
+}
+
+// writeSeatingSheet ajoute la feuille du plan de table : une ligne par foyer
+// placé, groupée par table, puis les personnes restant à placer
+func (s *ExportService) writeSeatingSheet(f *excelize.File) error {
+	plan, err := s.seatingService.Plan()
+	if err != nil {
+		return err
+	}
+
+	sheetName := "Plan de table"
+	if _, err := f.NewSheet(sheetName); err != nil {
+		return err
+	}
+
+	headers := []string{"Table", "Places", "Prénom", "Nom", "Adultes", "Enfants", "Allergies/Régimes"}
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellValue(sheetName, cell, header)
+	}
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Size: 12},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#E8E8E8"}, Pattern: 1},
+		Alignment: &excelize.Alignment{
+			Horizontal: "center",
+			Vertical:   "center",
+		},
+	})
+	if err == nil {
+		f.SetCellStyle(sheetName, "A1", "G1", headerStyle)
+	}
+
+	row := 2
+	writeParty := func(table, places string, party SeatedParty) {
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), table)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), places)
+		if party.RSVP != nil {
+			f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), party.RSVP.FirstName)
+			f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), party.RSVP.LastName)
+			f.SetCellValue(sheetName, fmt.Sprintf("G%d", row), party.RSVP.Allergies)
+		}
+		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), party.Adults)
+		f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), party.Children)
+		row++
+	}
+
+	for _, table := range plan.Tables {
+		places := fmt.Sprintf("%d / %d", table.Occupancy(), table.Capacity)
+		if len(table.Parties) == 0 {
+			writeParty(table.Name, places, SeatedParty{})
+		}
+		for _, party := range table.Parties {
+			writeParty(table.Name, places, party)
+		}
+	}
+	for _, party := range plan.Unseated {
+		writeParty("À placer", "", party)
+	}
+
+	f.SetColWidth(sheetName, "A", "A", 20)
+	f.SetColWidth(sheetName, "B", "B", 10)
+	f.SetColWidth(sheetName, "C", "D", 15)
+	f.SetColWidth(sheetName, "E", "F", 10)
+	f.SetColWidth(sheetName, "G", "G", 30)
+
+	return nil
+}
+
+// ExportCarpoolToExcel exporte le tableau du covoiturage : une feuille avec
+// toutes les annonces et une feuille de synthèse pour organiser les navettes
+func (s *ExportService) ExportCarpoolToExcel(board []CarpoolBoardLeg) (*excelize.File, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Size: 12},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#E8E8E8"}, Pattern: 1},
+		Alignment: &excelize.Alignment{
+			Horizontal: "center",
+			Vertical:   "center",
+		},
+	})
+
+	// Feuille des annonces
+	sheetName := "Covoiturage"
+	index, err := f.NewSheet(sheetName)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := []string{"Trajet", "Type", "Nom", "Contact", "Places", "Conducteur", "Précisions", "Date"}
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellValue(sheetName, cell, header)
+	}
+	f.SetCellStyle(sheetName, "A1", "H1", headerStyle)
+
+	row := 2
+	writeEntry := func(leg domain.CarpoolLeg, entry *domain.CarpoolEntry, kind, driver string) {
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), carpoolLegLabel(leg))
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), kind)
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), entry.Name)
+		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), entry.Contact)
+		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), entry.Seats)
+		f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), driver)
+		f.SetCellValue(sheetName, fmt.Sprintf("G%d", row), entry.Notes)
+		f.SetCellValue(sheetName, fmt.Sprintf("H%d", row), entry.CreatedAt.Format("02/01/2006 15:04"))
+		row++
+	}
+	for _, leg := range board {
+		for _, match := range leg.Matches {
+			writeEntry(leg.Leg, match.Offer, "Conducteur", "")
+			for _, passenger := range match.Passengers {
+				writeEntry(leg.Leg, passenger, "Passager", match.Offer.Name)
+			}
+		}
+		for _, request := range leg.Unmatched {
+			writeEntry(leg.Leg, request, "Passager", "Navette")
+		}
+	}
+
+	f.SetColWidth(sheetName, "A", "A", 40)
+	f.SetColWidth(sheetName, "B", "B", 12)
+	f.SetColWidth(sheetName, "C", "D", 22)
+	f.SetColWidth(sheetName, "E", "E", 10)
+	f.SetColWidth(sheetName, "F", "F", 18)
+	f.SetColWidth(sheetName, "G", "G", 40)
+	f.SetColWidth(sheetName, "H", "H", 18)
+	f.AutoFilter(sheetName, "A1:H1", []excelize.AutoFilterOptions{})
+
+	// Feuille de synthèse par trajet
+	summaryName := "Navettes"
+	if _, err := f.NewSheet(summaryName); err != nil {
+		return nil, err
+	}
+
+	summaryHeaders := []string{"Trajet", "Places offertes", "Places demandées", "Places sans solution"}
+	for i, header := range summaryHeaders {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellValue(summaryName, cell, header)
+	}
+	f.SetCellStyle(summaryName, "A1", "D1", headerStyle)
+
+	for i, leg := range board {
+		row := i + 2
+		f.SetCellValue(summaryName, fmt.Sprintf("A%d", row), carpoolLegLabel(leg.Leg))
+		f.SetCellValue(summaryName, fmt.Sprintf("B%d", row), leg.SeatsOffered)
+		f.SetCellValue(summaryName, fmt.Sprintf("C%d", row), leg.SeatsRequested)
+		f.SetCellValue(summaryName, fmt.Sprintf("D%d", row), leg.ShuttleSeats())
+	}
+
+	f.SetColWidth(summaryName, "A", "A", 40)
+	f.SetColWidth(summaryName, "B", "D", 20)
+
+	f.SetActiveSheet(index)
+	f.DeleteSheet("Sheet1")
+
+	return f, nil
+}
+
+// GetCarpoolFileName génère un nom de fichier pour l'export du covoiturage
+func (s *ExportService) GetCarpoolFileName() string {
+	return fmt.Sprintf("covoiturage-mariage-%s.xlsx", time.Now().Format("2006-01-02"))
+}
+
+// carpoolLegLabel décrit un trajet ("Cérémonie civile (Mairie) → Vin d'honneur (La Bergerie)")
+func carpoolLegLabel(leg domain.CarpoolLeg) string {
+	return fmt.Sprintf("%s (%s) → %s (%s)", leg.From.Title, leg.From.Venue.Name, leg.To.Title, leg.To.Venue.Name)
+}
+
+// ExportSongsToCSV exporte le classement des suggestions musicales en CSV
+// (UTF-8 avec BOM pour une ouverture directe dans Excel)
+func (s *ExportService) ExportSongsToCSV(w io.Writer, ranking []RankedSong) error {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"Rang", "Titre", "Artiste", "Lien", "Votes", "Proposé par"})
+	for _, song := range ranking {
+		names := make([]string, 0, len(song.Suggestions))
+		for _, suggestion := range song.Suggestions {
+			if suggestion.SuggestedBy != "" {
+				names = append(names, suggestion.SuggestedBy)
+			}
+		}
+		writer.Write([]string{
+			strconv.Itoa(song.Rank),
+			csvSafe(song.Title),
+			csvSafe(song.Artist),
+			csvSafe(song.Link),
+			strconv.Itoa(song.Votes()),
+			csvSafe(strings.Join(names, ", ")),
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvSafe neutralise une cellule saisie par un invité qu'Excel
+// interpréterait comme une formule (=HYPERLINK(...), =cmd|...) en la
+// préfixant d'une apostrophe
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// ExportSongsToM3U exporte le classement au format playlist M3U étendu pour le DJ :
+// le lien d'écoute sert d'emplacement, à défaut "Artiste - Titre"
+func (s *ExportService) ExportSongsToM3U(w io.Writer, ranking []RankedSong) error {
+	writer := bufio.NewWriter(w)
+	writer.WriteString("#EXTM3U\n")
+	for _, song := range ranking {
+		label := m3uText(song.Artist) + " - " + m3uText(song.Title)
+		location := song.Link
+		if location == "" {
+			location = label
+		}
+		fmt.Fprintf(writer, "#EXTINF:-1,%s\n%s\n", label, location)
+	}
+	return writer.Flush()
+}
+
+// m3uText retire les retours à la ligne qui casseraient le format M3U
+func m3uText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// GetSongsFileName génère un nom de fichier pour l'export de la playlist
+func (s *ExportService) GetSongsFileName(extension string) string {
+	return fmt.Sprintf("playlist-mariage-%s.%s", time.Now().Format("2006-01-02"), extension)
+}
+
+// ExportRegistryToExcel exporte les réservations de la liste de mariage
+// (qui offre quoi), pour les cartes de remerciement. Les noms des invités
+// anonymes y figurent : l'anonymat ne vaut qu'envers les autres invités.
+func (s *ExportService) ExportRegistryToExcel(items []*domain.RegistryItem) (*excelize.File, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Size: 12},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#E8E8E8"}, Pattern: 1},
+		Alignment: &excelize.Alignment{
+			Horizontal: "center",
+			Vertical:   "center",
+		},
+	})
+
+	sheetName := "Cadeaux"
+	index, err := f.NewSheet(sheetName)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := []string{"Cadeau", "Type", "Montant (€)", "Offert par", "Anonyme", "Date"}
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellValue(sheetName, cell, header)
+	}
+	f.SetCellStyle(sheetName, "A1", "F1", headerStyle)
+
+	row := 2
+	for _, item := range items {
+		kind := "Cadeau"
+		if item.Fund {
+			kind = "Cagnotte"
+		}
+		for _, reservation := range item.Reservations {
+			anonymous := "Non"
+			if reservation.Anonymous {
+				anonymous = "Oui"
+			}
+			f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), item.Title)
+			f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), kind)
+			f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), reservation.Amount)
+			f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), reservation.Name)
+			f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), anonymous)
+			f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), reservation.ReservedAt.Format("02/01/2006 15:04"))
+			row++
+		}
+	}
+
+	f.SetColWidth(sheetName, "A", "A", 40)
+	f.SetColWidth(sheetName, "B", "C", 14)
+	f.SetColWidth(sheetName, "D", "D", 28)
+	f.SetColWidth(sheetName, "E", "E", 10)
+	f.SetColWidth(sheetName, "F", "F", 18)
+	f.AutoFilter(sheetName, "A1:F1", []excelize.AutoFilterOptions{})
+
+	f.SetActiveSheet(index)
+	f.DeleteSheet("Sheet1")
+
+	return f, nil
+}
+
+// GetRegistryFileName génère un nom de fichier pour l'export de la liste de mariage
+func (s *ExportService) GetRegistryFileName() string {
+	return fmt.Sprintf("liste-mariage-%s.xlsx", time.Now().Format("2006-01-02"))
+}
+
+// ExportThankYousToCSV exporte le suivi des remerciements pour un
+// publipostage : une ligne par foyer avec son adresse postale (UTF-8 avec
+// BOM pour une ouverture directe dans Excel)
+func (s *ExportService) ExportThankYousToCSV(w io.Writer, households []ThankYouHousehold) error {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"Foyer", "Prénom", "Nom", "Adresse", "Code postal", "Ville", "Pays", "Langue", "Cadeau reçu", "Liste de mariage", "Note", "Remercié le"})
+	for _, household := range households {
+		firstName, lastName, lang := "", "", ""
+		if household.RSVP != nil {
+			firstName, lastName, lang = household.RSVP.FirstName, household.RSVP.LastName, strings.ToUpper(household.RSVP.Lang)
+		}
+		record := household.Record
+		sentAt := ""
+		if record.IsSent() {
+			sentAt = record.SentAt.Format("02/01/2006")
+		}
+		writer.Write([]string{
+			csvSafe(household.Name),
+			csvSafe(firstName),
+			csvSafe(lastName),
+			csvSafe(record.Address.Street),
+			csvSafe(record.Address.PostalCode),
+			csvSafe(record.Address.City),
+			csvSafe(record.Address.Country),
+			csvSafe(lang),
+			csvSafe(record.Gift),
+			csvSafe(strings.Join(household.RegistryGifts, ", ")),
+			csvSafe(record.Note),
+			sentAt,
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// GetThankYousFileName génère un nom de fichier pour l'export des remerciements
+func (s *ExportService) GetThankYousFileName() string {
+	return fmt.Sprintf("remerciements-mariage-%s.csv", time.Now().Format("2006-01-02"))
+}
+
 // GetFileName génère un nom de fichier pour l'export
 func (s *ExportService) GetFileName() string {
 	return fmt.Sprintf("rsvp-mariage-%s.xlsx", time.Now().Format("2006-01-02"))
@@ -0,0 +1,101 @@
+package application
+
+import (
+	"errors"
+	"sort"
+	"time"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/domain/ports"
+)
+
+var (
+	ErrGuestbookEntryNotFound = errors.New("message du livre d'or introuvable")
+)
+
+// GuestbookService gère le livre d'or et sa modération
+type GuestbookService struct {
+	storage ports.GuestbookStorage
+}
+
+// NewGuestbookService crée un nouveau service de livre d'or
+func NewGuestbookService(storage ports.GuestbookStorage) *GuestbookService {
+	return &GuestbookService{
+		storage: storage,
+	}
+}
+
+// PostEntry enregistre un message en attente de modération
+func (s *GuestbookService) PostEntry(invitation *domain.Invitation, name, message, ipAddress string) (*domain.GuestbookEntry, error) {
+	entry, err := domain.NewGuestbookEntry(name, message)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.ID = generateID()
+	entry.IPAddress = ipAddress
+	if invitation != nil {
+		entry.InvitationID = invitation.ID
+	}
+
+	if err := s.storage.Save(entry); err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	return entry, nil
+}
+
+// Approved retourne les messages publiés, les plus récents en premier
+func (s *GuestbookService) Approved() ([]*domain.GuestbookEntry, error) {
+	return s.list(true)
+}
+
+// Pending retourne la file de modération, les plus anciens en premier
+func (s *GuestbookService) Pending() ([]*domain.GuestbookEntry, error) {
+	return s.list(false)
+}
+
+// Approve publie un message de la file de modération
+func (s *GuestbookService) Approve(id string) error {
+	entry, err := s.storage.FindByID(id)
+	if err != nil {
+		return ErrGuestbookEntryNotFound
+	}
+
+	entry.Approve(time.Now())
+	if err := s.storage.Save(entry); err != nil {
+		return ErrStorageFailure
+	}
+	return nil
+}
+
+// DeleteEntry supprime un message, publié ou refusé (administration)
+func (s *GuestbookService) DeleteEntry(id string) error {
+	if err := s.storage.Delete(id); err != nil {
+		return ErrStorageFailure
+	}
+	return nil
+}
+
+// list retourne les messages publiés (du plus récent au plus ancien)
+// ou en attente (du plus ancien au plus récent)
+func (s *GuestbookService) list(approved bool) ([]*domain.GuestbookEntry, error) {
+	entries, err := s.storage.FindAll()
+	if err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	var selected []*domain.GuestbookEntry
+	for _, entry := range entries {
+		if entry.IsApproved() == approved {
+			selected = append(selected, entry)
+		}
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		if approved {
+			return selected[i].ApprovedAt.After(selected[j].ApprovedAt)
+		}
+		return selected[i].CreatedAt.Before(selected[j].CreatedAt)
+	})
+	return selected, nil
+}
@@ -1,20 +1,58 @@
 package application
 
-import "wedding-web/internal/domain"
+import (
+	"errors"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/domain/ports"
+	"wedding-web/internal/i18n"
+)
+
+var (
+	ErrContentUnavailable = errors.New("contenu indisponible")
+)
 
 // InfoService gère la logique métier des infos pratiques
 type InfoService struct {
-	info *domain.PracticalInfo
+	source ports.PracticalInfoSource
+	venues []*domain.Venue
 }
 
 // NewInfoService crée un nouveau service d'infos
-func NewInfoService() *InfoService {
+func NewInfoService(source ports.PracticalInfoSource) *InfoService {
 	return &InfoService{
-		info: domain.GetDefaultPracticalInfo(),
+		source: source,
+		venues: domain.GetDefaultVenues(),
 	}
 }
 
-// GetPracticalInfo retourne les informations pratiques
-func (s *InfoService) GetPracticalInfo() *domain.PracticalInfo {
-	return s.info
+// GetPracticalInfo retourne les informations pratiques dans la langue donnée.
+// Si elles ne sont pas rédigées dans cette langue, la version française est utilisée.
+func (s *InfoService) GetPracticalInfo(lang i18n.Lang) (*domain.PracticalInfo, error) {
+	info, err := s.source.LoadPracticalInfo(string(lang))
+	if err != nil && lang != i18n.Default {
+		info, err = s.source.LoadPracticalInfo(string(i18n.Default))
+	}
+	if err != nil {
+		return nil, errors.Join(ErrContentUnavailable, err)
+	}
+
+	// Les lieux sont partagés avec le planning et traduits à la volée
+	venues := make([]*domain.Venue, len(s.venues))
+	for i, venue := range s.venues {
+		venues[i] = venue.In(string(lang))
+	}
+
+	return &domain.PracticalInfo{
+		Sections: info.Sections,
+		Venues:   venues,
+	}, nil
+}
+
+// GetPracticalInfoFor retourne les informations pratiques visibles par l'invitation
+func (s *InfoService) GetPracticalInfoFor(invitation *domain.Invitation, lang i18n.Lang) (*domain.PracticalInfo, error) {
+	info, err := s.GetPracticalInfo(lang)
+	if err != nil {
+		return nil, err
+	}
+	return info.VisibleTo(invitation), nil
 }
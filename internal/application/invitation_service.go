@@ -0,0 +1,137 @@
+package application
+
+import (
+	"crypto/rand"
+	"errors"
+	"strings"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/domain/ports"
+	"wedding-web/internal/i18n"
+)
+
+var (
+	ErrInvitationNotFound = errors.New("invitation introuvable")
+)
+
+// invitationCodeAlphabet exclut les caractères ambigus (0/o, 1/l/i)
+const invitationCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// InvitationService gère la logique métier des invitations
+type InvitationService struct {
+	storage ports.InvitationStorage
+}
+
+// NewInvitationService crée un nouveau service d'invitations
+func NewInvitationService(storage ports.InvitationStorage) *InvitationService {
+	return &InvitationService{
+		storage: storage,
+	}
+}
+
+// CreateInvitation crée une invitation pour un foyer.
+// Les groupes sont fournis séparés par des virgules (ex: "famille, temoins").
+// L'adresse et la langue du foyer, facultatives, servent aux relances.
+func (s *InvitationService) CreateInvitation(name, groups, email, lang string) (*domain.Invitation, error) {
+	invitation, err := domain.NewInvitation(name, strings.Split(groups, ","))
+	if err != nil {
+		return nil, err
+	}
+	if err := invitation.SetEmail(email); err != nil {
+		return nil, err
+	}
+	invitation.Lang = invitationLang(lang)
+
+	invitation.ID = generateID()
+	invitation.Code = generateInvitationCode()
+
+	if err := s.storage.Save(invitation); err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	return invitation, nil
+}
+
+// ListInvitations retourne toutes les invitations
+func (s *InvitationService) ListInvitations() ([]*domain.Invitation, error) {
+	invitations, err := s.storage.FindAll()
+	if err != nil {
+		return nil, ErrStorageFailure
+	}
+	return invitations, nil
+}
+
+// GetInvitationByCode retourne l'invitation associée à un code personnel
+func (s *InvitationService) GetInvitationByCode(code string) (*domain.Invitation, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "" {
+		return nil, ErrInvitationNotFound
+	}
+
+	invitation, err := s.storage.FindByCode(code)
+	if err != nil {
+		return nil, ErrInvitationNotFound
+	}
+	return invitation, nil
+}
+
+// GetInvitation retourne une invitation par son ID
+func (s *InvitationService) GetInvitation(id string) (*domain.Invitation, error) {
+	if id == "" {
+		return nil, ErrInvitationNotFound
+	}
+
+	invitation, err := s.storage.FindByID(id)
+	if err != nil {
+		return nil, ErrInvitationNotFound
+	}
+	return invitation, nil
+}
+
+// UpdateContact modifie l'adresse et la langue d'un foyer
+func (s *InvitationService) UpdateContact(id, email, lang string) error {
+	invitation, err := s.GetInvitation(id)
+	if err != nil {
+		return err
+	}
+	if err := invitation.SetEmail(email); err != nil {
+		return err
+	}
+	invitation.Lang = invitationLang(lang)
+
+	if err := s.storage.Save(invitation); err != nil {
+		return ErrStorageFailure
+	}
+	return nil
+}
+
+// DeleteInvitation supprime une invitation par son ID
+func (s *InvitationService) DeleteInvitation(id string) error {
+	if err := s.storage.Delete(id); err != nil {
+		return ErrStorageFailure
+	}
+	return nil
+}
+
+// invitationLang retourne la langue disponible correspondant au code donné
+// (vide : langue par défaut)
+func invitationLang(code string) string {
+	if lang, ok := i18n.Parse(code); ok {
+		return string(lang)
+	}
+	return ""
+}
+
+// generateInvitationCode génère un code court, lisible et difficile à deviner
+func generateInvitationCode() string {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		// Fallback sur un identifiant complet en cas d'erreur (très rare)
+		return generateID()
+	}
+
+	code := make([]byte, len(b))
+	for i, v := range b {
+		code[i] = invitationCodeAlphabet[int(v)%len(invitationCodeAlphabet)]
+	}
+	return string(code)
+}
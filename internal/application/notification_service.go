@@ -0,0 +1,317 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/domain/ports"
+	"wedding-web/internal/i18n"
+)
+
+// NotificationMode choisit quand prévenir les mariés des nouvelles réponses
+type NotificationMode string
+
+const (
+	NotifyEachRSVP NotificationMode = "instant" // Un email par réponse
+	NotifyDigest   NotificationMode = "digest"  // Un récapitulatif quotidien
+)
+
+// queuePollInterval est la fréquence de traitement de la file d'envoi
+const queuePollInterval = time.Minute
+
+// rsvpNotification est le contenu du template rsvp_notification.txt
+type rsvpNotification struct {
+	RSVP       *domain.RSVP
+	Invitation string // Nom du foyer invité (vide : réponse sans lien personnel)
+	Digest     bool   // Rédigé pour le récapitulatif quotidien
+	AdminURL   string
+}
+
+// guestConfirmation est le contenu des templates guest_confirmation.<lang>.txt
+type guestConfirmation struct {
+	RSVP    *domain.RSVP
+	Lang    string
+	Nights  []time.Time // Nuits d'hébergement demandées
+	EditURL string      // Lien de modification de la réponse
+}
+
+// invitationReminder est le contenu des templates reminder.<lang>.txt
+type invitationReminder struct {
+	Invitation *domain.Invitation
+	Lang       string
+	Deadline   time.Time // Date limite de réponse
+	RSVPURL    string    // Lien personnel vers le formulaire de réponse
+}
+
+// rsvpDigest est le contenu du template rsvp_digest.txt
+type rsvpDigest struct {
+	Date     time.Time
+	Entries  []domain.Email // Notifications des réponses, par ordre d'arrivée
+	AdminURL string
+}
+
+// NotificationService prévient les mariés des nouvelles réponses par email,
+// et confirme leur réponse aux invités qui ont laissé leur adresse. Les
+// emails passent par une file persistée : un envoi en échec est réessayé
+// plus tard, jusqu'à maxAttempts tentatives.
+type NotificationService struct {
+	mailer          ports.Mailer
+	queue           ports.EmailQueueStorage
+	calendarService *CalendarService // Calendrier joint aux confirmations
+	templates       *emailTemplates
+	recipients      []string // Adresses des mariés (vide : pas de notification)
+	confirmGuests   bool     // Confirmer leur réponse aux invités
+	mode            NotificationMode
+	digestTime      time.Duration // Heure d'envoi du récapitulatif quotidien (depuis minuit)
+	maxAttempts     int
+	adminURL        string
+	wake            chan struct{} // Traite la file sans attendre le prochain passage
+	mu              sync.Mutex    // Un seul traitement de la file à la fois
+}
+
+// NewNotificationService crée le service de notifications ; les templates
+// d'emails sont lus dans templatesDir
+func NewNotificationService(mailer ports.Mailer, queue ports.EmailQueueStorage, calendarService *CalendarService, templatesDir string, recipients []string, confirmGuests bool, mode NotificationMode, digestTime time.Duration, maxAttempts int, baseURL string) (*NotificationService, error) {
+	templates, err := loadEmailTemplates(templatesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NotificationService{
+		mailer:          mailer,
+		queue:           queue,
+		calendarService: calendarService,
+		templates:       templates,
+		recipients:      recipients,
+		confirmGuests:   confirmGuests,
+		mode:            mode,
+		digestTime:      digestTime,
+		maxAttempts:     maxAttempts,
+		adminURL:        baseURL + "/admin",
+		wake:            make(chan struct{}, 1),
+	}, nil
+}
+
+// RSVPSubmitted prévient les mariés d'une nouvelle réponse : tout de suite,
+// ou dans le prochain récapitulatif quotidien
+func (s *NotificationService) RSVPSubmitted(rsvp *domain.RSVP, invitation *domain.Invitation) error {
+	if len(s.recipients) == 0 {
+		return nil
+	}
+
+	data := rsvpNotification{
+		RSVP:     rsvp,
+		Digest:   s.mode == NotifyDigest,
+		AdminURL: s.adminURL,
+	}
+	if invitation != nil {
+		data.Invitation = invitation.Name
+	}
+
+	email, err := s.templates.render("rsvp_notification.txt", s.recipients, data)
+	if err != nil {
+		return err
+	}
+
+	if s.mode == NotifyDigest {
+		return s.enqueue(email, nextDigest(time.Now(), s.digestTime), true)
+	}
+	return s.enqueue(email, time.Now(), false)
+}
+
+// ConfirmsGuests indique si les invités peuvent recevoir la confirmation
+// de leur réponse (le formulaire demande alors leur adresse)
+func (s *NotificationService) ConfirmsGuests() bool {
+	return s.confirmGuests
+}
+
+// ConfirmRSVP envoie à l'invité la confirmation de sa réponse, dans sa
+// langue, avec le lien de modification et, s'il vient, le programme du
+// foyer en calendrier (.ics) joint. Sans adresse, rien n'est envoyé.
+func (s *NotificationService) ConfirmRSVP(rsvp *domain.RSVP, planning *domain.Planning, editURL string) error {
+	if !s.confirmGuests || rsvp.Email == "" {
+		return nil
+	}
+
+	lang := i18n.Lang(rsvp.Lang)
+	data := guestConfirmation{RSVP: rsvp, Lang: rsvp.Lang, EditURL: editURL}
+	for _, night := range rsvp.AccommodationNights {
+		if date, err := time.Parse(domain.NightDateFormat, night); err == nil {
+			data.Nights = append(data.Nights, date)
+		}
+	}
+
+	email, err := s.templates.render(s.templates.localized("guest_confirmation", lang), []string{rsvp.Email}, data)
+	if err != nil {
+		return err
+	}
+
+	if rsvp.WillAttend {
+		ics, err := s.calendarService.GenerateICS(planning, rsvp.Lang)
+		if err != nil {
+			return err
+		}
+		email.Attachments = []domain.Attachment{{
+			Filename:    "mariage-2026.ics",
+			ContentType: "text/calendar; charset=utf-8; method=PUBLISH",
+			Data:        ics,
+		}}
+	}
+
+	return s.Enqueue(email)
+}
+
+// RemindInvitation relance un foyer qui n'a pas encore répondu, dans sa
+// langue, avec son lien personnel vers le formulaire de réponse
+func (s *NotificationService) RemindInvitation(invitation *domain.Invitation, deadline time.Time, rsvpURL string) error {
+	lang := i18n.Default
+	if invitation.Lang != "" {
+		lang = i18n.Lang(invitation.Lang)
+	}
+
+	data := invitationReminder{
+		Invitation: invitation,
+		Lang:       string(lang),
+		Deadline:   deadline,
+		RSVPURL:    rsvpURL,
+	}
+	email, err := s.templates.render(s.templates.localized("reminder", lang), []string{invitation.Email}, data)
+	if err != nil {
+		return err
+	}
+	return s.Enqueue(email)
+}
+
+// Enqueue ajoute un email à la file d'envoi, pour un envoi immédiat
+func (s *NotificationService) Enqueue(email domain.Email) error {
+	return s.enqueue(email, time.Now(), false)
+}
+
+// enqueue ajoute un email à la file d'envoi, pour la date donnée
+func (s *NotificationService) enqueue(email domain.Email, sendAt time.Time, digest bool) error {
+	queued, err := domain.NewQueuedEmail(email, sendAt)
+	if err != nil {
+		return err
+	}
+	queued.ID = generateID()
+	queued.Digest = digest
+
+	if err := s.queue.Save(queued); err != nil {
+		return ErrStorageFailure
+	}
+
+	// Réveiller Run si l'email doit partir tout de suite
+	if queued.IsDue(time.Now()) {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// ProcessQueue envoie les emails dont la date est passée : les éléments du
+// récapitulatif partent ensemble dans un seul email. Retourne les erreurs
+// d'envoi (les emails concernés restent dans la file).
+func (s *NotificationService) ProcessQueue(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	queued, err := s.queue.FindAll()
+	if err != nil {
+		return ErrStorageFailure
+	}
+
+	var errs []error
+	var digest []*domain.QueuedEmail
+	for _, email := range queued {
+		if !email.IsDue(now) {
+			continue
+		}
+		if email.Digest {
+			digest = append(digest, email)
+			continue
+		}
+		errs = append(errs, s.send(email.Email, []*domain.QueuedEmail{email}, now))
+	}
+
+	if len(digest) > 0 {
+		sort.Slice(digest, func(i, j int) bool {
+			return digest[i].CreatedAt.Before(digest[j].CreatedAt)
+		})
+		data := rsvpDigest{Date: now, AdminURL: s.adminURL}
+		for _, email := range digest {
+			data.Entries = append(data.Entries, email.Email)
+		}
+		email, err := s.templates.render("rsvp_digest.txt", s.recipients, data)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			errs = append(errs, s.send(email, digest, now))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// send envoie un email et le retire de la file, avec les éléments qu'il
+// regroupe ; en cas d'échec, ils sont reprogrammés ou abandonnés
+func (s *NotificationService) send(email domain.Email, queued []*domain.QueuedEmail, now time.Time) error {
+	sendErr := s.mailer.Send(email)
+
+	var errs []error
+	if sendErr != nil {
+		errs = append(errs, fmt.Errorf("envoi de %q: %w", email.Subject, sendErr))
+	}
+	for _, item := range queued {
+		if sendErr != nil {
+			item.Failed(sendErr, now)
+			if item.Attempts < s.maxAttempts {
+				if err := s.queue.Save(item); err != nil {
+					errs = append(errs, ErrStorageFailure)
+				}
+				continue
+			}
+			errs = append(errs, fmt.Errorf("email %q abandonné après %d tentatives", item.Email.Subject, item.Attempts))
+		}
+		if err := s.queue.Delete(item.ID); err != nil {
+			errs = append(errs, ErrStorageFailure)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run traite la file d'envoi jusqu'à l'annulation du contexte : chaque
+// minute, et dès qu'un email est à envoyer
+func (s *NotificationService) Run(ctx context.Context) {
+	ticker := time.NewTicker(queuePollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ProcessQueue(time.Now()); err != nil {
+			log.Printf("Envoi des emails: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// nextDigest retourne la prochaine heure d'envoi du récapitulatif quotidien
+func nextDigest(now time.Time, at time.Duration) time.Time {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	next := midnight.Add(at)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
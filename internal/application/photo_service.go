@@ -0,0 +1,155 @@
+package application
+
+import (
+	"errors"
+	"sort"
+	"time"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/domain/ports"
+	"wedding-web/internal/imaging"
+)
+
+var (
+	ErrPhotoNotFound = errors.New("photo introuvable")
+)
+
+const (
+	photoMaxSide       = 2048 // Plus grand côté des photos affichées, en pixels
+	photoThumbnailSide = 400  // Plus grand côté des miniatures de la galerie
+)
+
+// PhotoService gère les photos partagées par les invités et leur modération
+type PhotoService struct {
+	storage ports.PhotoStorage
+	files   ports.PhotoFileStore
+}
+
+// NewPhotoService crée un nouveau service de photos
+func NewPhotoService(storage ports.PhotoStorage, files ports.PhotoFileStore) *PhotoService {
+	return &PhotoService{
+		storage: storage,
+		files:   files,
+	}
+}
+
+// Upload enregistre une photo envoyée par un foyer invité, en attente de
+// modération. L'image est réencodée (métadonnées EXIF supprimées) et une
+// miniature est générée.
+func (s *PhotoService) Upload(invitation *domain.Invitation, uploadedBy, caption, contentType string, data []byte) (*domain.Photo, error) {
+	if invitation == nil {
+		return nil, ErrInvitationRequired
+	}
+
+	if err := domain.CheckPhotoUpload(contentType, len(data)); err != nil {
+		return nil, err
+	}
+
+	photo, err := domain.NewPhoto(uploadedBy, caption)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := imaging.Process(data, photoMaxSide, photoThumbnailSide)
+	if err == imaging.ErrImageTooLarge {
+		return nil, domain.ErrPhotoTooLarge
+	}
+	if err != nil {
+		return nil, domain.ErrInvalidPhoto
+	}
+
+	photo.ID = generateID()
+	photo.InvitationID = invitation.ID
+	photo.Width = images.Width
+	photo.Height = images.Height
+
+	if err := s.files.SaveImages(photo.ID, images.Photo, images.Thumbnail); err != nil {
+		return nil, ErrStorageFailure
+	}
+	if err := s.storage.Save(photo); err != nil {
+		s.files.DeleteImages(photo.ID)
+		return nil, ErrStorageFailure
+	}
+
+	return photo, nil
+}
+
+// Approved retourne les photos de la galerie, les plus récentes en premier
+func (s *PhotoService) Approved() ([]*domain.Photo, error) {
+	return s.list(true)
+}
+
+// Pending retourne la file de modération, les plus anciennes en premier
+func (s *PhotoService) Pending() ([]*domain.Photo, error) {
+	return s.list(false)
+}
+
+// Approve publie une photo dans la galerie
+func (s *PhotoService) Approve(id string) error {
+	photo, err := s.storage.FindByID(id)
+	if err != nil {
+		return ErrPhotoNotFound
+	}
+
+	photo.Approve(time.Now())
+	if err := s.storage.Save(photo); err != nil {
+		return ErrStorageFailure
+	}
+	return nil
+}
+
+// DeletePhoto supprime une photo et ses fichiers (administration)
+func (s *PhotoService) DeletePhoto(id string) error {
+	if _, err := s.storage.FindByID(id); err != nil {
+		return ErrPhotoNotFound
+	}
+
+	if err := s.files.DeleteImages(id); err != nil {
+		return ErrStorageFailure
+	}
+	if err := s.storage.Delete(id); err != nil {
+		return ErrStorageFailure
+	}
+	return nil
+}
+
+// PublicImage retourne l'image d'une photo publiée (ou sa miniature)
+func (s *PhotoService) PublicImage(id string, thumbnail bool) ([]byte, error) {
+	photo, err := s.storage.FindByID(id)
+	if err != nil || !photo.IsApproved() {
+		return nil, ErrPhotoNotFound
+	}
+	return s.Image(id, thumbnail)
+}
+
+// Image retourne l'image d'une photo, publiée ou non (administration)
+func (s *PhotoService) Image(id string, thumbnail bool) ([]byte, error) {
+	data, err := s.files.ReadImage(id, thumbnail)
+	if err != nil {
+		return nil, ErrPhotoNotFound
+	}
+	return data, nil
+}
+
+// list retourne les photos publiées (de la plus récente à la plus ancienne)
+// ou en attente (de la plus ancienne à la plus récente)
+func (s *PhotoService) list(approved bool) ([]*domain.Photo, error) {
+	photos, err := s.storage.FindAll()
+	if err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	var selected []*domain.Photo
+	for _, photo := range photos {
+		if photo.IsApproved() == approved {
+			selected = append(selected, photo)
+		}
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		if approved {
+			return selected[i].ApprovedAt.After(selected[j].ApprovedAt)
+		}
+		return selected[i].UploadedAt.Before(selected[j].UploadedAt)
+	})
+	return selected, nil
+}
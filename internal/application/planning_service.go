@@ -18,3 +18,9 @@ func NewPlanningService() *PlanningService {
 func (s *PlanningService) GetPlanning() *domain.Planning {
 	return s.planning
 }
+
+// GetPlanningFor retourne le planning limité aux événements de l'invitation.
+// Sans invitation, seuls les événements publics sont retournés.
+func (s *PlanningService) GetPlanningFor(invitation *domain.Invitation) *domain.Planning {
+	return s.planning.VisibleTo(invitation)
+}
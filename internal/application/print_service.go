@@ -0,0 +1,400 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/i18n"
+
+	"github.com/jung-kurt/gofpdf"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+var (
+	ErrUnknownDocument = errors.New("document inconnu")
+)
+
+// PrintDocument identifie un document à imprimer généré depuis le plan de table
+type PrintDocument string
+
+const (
+	PrintEscortCards PrintDocument = "escort" // Cartes d'accueil : chaque foyer et sa table
+	PrintPlaceCards  PrintDocument = "place"  // Marque-places : un par personne placée
+	PrintTablePlan   PrintDocument = "plan"   // Plan de table grand format
+)
+
+// PrintCard est une carte à imprimer (carte d'accueil ou marque-place),
+// rédigée dans la langue de l'invité
+type PrintCard struct {
+	Lang   string
+	Name   string
+	Detail string // Ligne secondaire (nombre de personnes), éventuellement vide
+	Table  string
+}
+
+// Dimensions des cartes (mm) : les cartes d'accueil sont imprimées à plat,
+// les marque-places sont pliés en deux (chevalet)
+const (
+	escortCardWidth  = 90.0
+	escortCardHeight = 55.0
+	placeCardWidth   = 90.0
+	placeCardHeight  = 90.0
+)
+
+// printFont est la police des documents : les polices Go, embarquées en
+// UTF-8, couvrent le latin étendu, le grec et le cyrillique (Łukasz,
+// Dvořák, Özdemir, Ζωή…). Les écritures hors de ce jeu (chinois, arabe…)
+// s'impriment en caractères manquants.
+const printFont = "Go"
+
+// tablePlanSize est le format du plan de table : A2 paysage (mm)
+var tablePlanSize = gofpdf.SizeType{Wd: 594, Ht: 420}
+
+// PrintService génère les documents PDF du plan de table
+type PrintService struct {
+	seatingService *SeatingService
+}
+
+// NewPrintService crée un nouveau service d'impression
+func NewPrintService(seatingService *SeatingService) *PrintService {
+	return &PrintService{
+		seatingService: seatingService,
+	}
+}
+
+// EscortCards retourne une carte d'accueil par foyer et par table, dans
+// l'ordre alphabétique des foyers
+func (s *PrintService) EscortCards(lang string) ([]PrintCard, error) {
+	plan, err := s.seatingService.Plan()
+	if err != nil {
+		return nil, err
+	}
+
+	var cards []PrintCard
+	for _, party := range seatedParties(plan) {
+		t := i18n.NewTranslations(i18n.Lang(guestLang(party.RSVP, lang)))
+		card := PrintCard{
+			Lang:  t.Lang(),
+			Name:  party.RSVP.FullName(),
+			Table: party.Table,
+		}
+		if persons := party.Adults + party.Children; persons > 1 {
+			card.Detail = t.T("print.persons", "count", persons)
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// PlaceCards retourne un marque-place par personne placée. Seul le nom du
+// foyer est connu : la première carte adulte porte ce nom, les autres
+// désignent les accompagnants et les enfants du foyer.
+func (s *PrintService) PlaceCards(lang string) ([]PrintCard, error) {
+	plan, err := s.seatingService.Plan()
+	if err != nil {
+		return nil, err
+	}
+
+	var cards []PrintCard
+	named := map[string]bool{}
+	for _, party := range seatedParties(plan) {
+		t := i18n.NewTranslations(i18n.Lang(guestLang(party.RSVP, lang)))
+		name := party.RSVP.FullName()
+
+		for i := 0; i < party.Adults; i++ {
+			card := PrintCard{Lang: t.Lang(), Name: name, Table: party.Table}
+			if named[party.RSVP.ID] {
+				card.Name = t.T("print.guest_of", "name", name)
+			}
+			named[party.RSVP.ID] = true
+			cards = append(cards, card)
+		}
+		for i := 0; i < party.Children; i++ {
+			cards = append(cards, PrintCard{Lang: t.Lang(), Name: t.T("print.child_of", "name", name), Table: party.Table})
+		}
+	}
+	return cards, nil
+}
+
+// WritePDF écrit le document demandé en PDF. La langue est celle des
+// invités dont la langue n'est pas connue, et celle du plan de table.
+func (s *PrintService) WritePDF(w io.Writer, document PrintDocument, lang string) error {
+	var pdf *gofpdf.Fpdf
+	switch document {
+	case PrintEscortCards:
+		cards, err := s.EscortCards(lang)
+		if err != nil {
+			return err
+		}
+		pdf = cardsPDF(cards, escortCardWidth, escortCardHeight, drawEscortCard)
+	case PrintPlaceCards:
+		cards, err := s.PlaceCards(lang)
+		if err != nil {
+			return err
+		}
+		pdf = cardsPDF(cards, placeCardWidth, placeCardHeight, drawPlaceCard)
+	case PrintTablePlan:
+		plan, err := s.seatingService.Plan()
+		if err != nil {
+			return err
+		}
+		pdf = tablePlanPDF(plan, i18n.NewTranslations(i18n.Lang(lang)))
+	default:
+		return ErrUnknownDocument
+	}
+
+	return pdf.Output(w)
+}
+
+// GetFileName génère un nom de fichier pour le document
+func (s *PrintService) GetFileName(document PrintDocument) string {
+	names := map[PrintDocument]string{
+		PrintEscortCards: "cartes-accueil",
+		PrintPlaceCards:  "marque-places",
+		PrintTablePlan:   "plan-de-table",
+	}
+	return fmt.Sprintf("%s-mariage-%s.pdf", names[document], time.Now().Format("2006-01-02"))
+}
+
+// guestLang retourne la langue dans laquelle imprimer les cartes d'un foyer :
+// celle de sa réponse, à défaut la langue choisie pour le document
+func guestLang(rsvp *domain.RSVP, fallback string) string {
+	if rsvp != nil {
+		if lang, ok := i18n.Parse(rsvp.Lang); ok {
+			return string(lang)
+		}
+	}
+	return fallback
+}
+
+// printedParty est un foyer placé à une table, avec le nom de la table
+type printedParty struct {
+	SeatedParty
+	Table string
+}
+
+// seatedParties retourne les foyers placés (une entrée par table), triés
+// par nom puis prénom. Les places des réponses supprimées sont ignorées.
+func seatedParties(plan *SeatingPlan) []printedParty {
+	var parties []printedParty
+	for _, table := range plan.Tables {
+		for _, party := range table.Parties {
+			if party.RSVP != nil {
+				parties = append(parties, printedParty{SeatedParty: party, Table: table.Name})
+			}
+		}
+	}
+
+	sort.SliceStable(parties, func(i, j int) bool {
+		a, b := parties[i].RSVP, parties[j].RSVP
+		if !strings.EqualFold(a.LastName, b.LastName) {
+			return strings.ToLower(a.LastName) < strings.ToLower(b.LastName)
+		}
+		return strings.ToLower(a.FirstName) < strings.ToLower(b.FirstName)
+	})
+	return parties
+}
+
+// newPDF crée un document avec la police d'impression en regular, gras et italique
+func newPDF(orientation string, size gofpdf.SizeType, title string) *gofpdf.Fpdf {
+	pdf := gofpdf.NewCustom(&gofpdf.InitType{
+		OrientationStr: orientation,
+		UnitStr:        "mm",
+		Size:           size,
+	})
+	pdf.AddUTF8FontFromBytes(printFont, "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes(printFont, "B", gobold.TTF)
+	pdf.AddUTF8FontFromBytes(printFont, "I", goitalic.TTF)
+	pdf.SetTitle(title, true)
+	pdf.SetCreator("wedding-web", true)
+	pdf.SetAutoPageBreak(false, 0)
+	return pdf
+}
+
+// cardsPDF dispose les cartes en grille sur des pages A4, avec des traits
+// de coupe en pointillés
+func cardsPDF(cards []PrintCard, width, height float64, draw func(*gofpdf.Fpdf, PrintCard, float64, float64)) *gofpdf.Fpdf {
+	const pageWidth, pageHeight = 210.0, 297.0
+	pdf := newPDF("P", gofpdf.SizeType{Wd: pageWidth, Ht: pageHeight}, "Cartes")
+
+	cols := int(pageWidth / width)
+	rows := int((pageHeight - 10) / height)
+	marginX := (pageWidth - float64(cols)*width) / 2
+	marginY := (pageHeight - float64(rows)*height) / 2
+
+	if len(cards) == 0 {
+		pdf.AddPage()
+	}
+	for i, card := range cards {
+		slot := i % (cols * rows)
+		if slot == 0 {
+			pdf.AddPage()
+		}
+		x := marginX + float64(slot%cols)*width
+		y := marginY + float64(slot/cols)*height
+
+		pdf.SetDrawColor(180, 180, 180)
+		pdf.SetLineWidth(0.2)
+		pdf.SetDashPattern([]float64{1, 1}, 0)
+		pdf.Rect(x, y, width, height, "D")
+		pdf.SetDashPattern([]float64{}, 0)
+
+		draw(pdf, card, x, y)
+	}
+	return pdf
+}
+
+// drawEscortCard dessine une carte d'accueil : nom du foyer et table
+func drawEscortCard(pdf *gofpdf.Fpdf, card PrintCard, x, y float64) {
+	t := i18n.NewTranslations(i18n.Lang(card.Lang))
+
+	pdf.SetTextColor(40, 40, 40)
+	fitFont(pdf, printFont, "I", 18, card.Name, escortCardWidth-10)
+	pdf.SetXY(x+5, y+10)
+	pdf.CellFormat(escortCardWidth-10, 10, card.Name, "", 0, "C", false, 0, "")
+
+	if card.Detail != "" {
+		pdf.SetFont(printFont, "", 9)
+		pdf.SetXY(x+5, y+20)
+		pdf.CellFormat(escortCardWidth-10, 5, card.Detail, "", 0, "C", false, 0, "")
+	}
+
+	pdf.SetFont(printFont, "", 10)
+	pdf.SetXY(x+5, y+30)
+	pdf.CellFormat(escortCardWidth-10, 6, t.T("print.table"), "", 0, "C", false, 0, "")
+	pdf.SetFont(printFont, "B", 16)
+	pdf.SetXY(x+5, y+37)
+	pdf.CellFormat(escortCardWidth-10, 9, card.Table, "", 0, "C", false, 0, "")
+}
+
+// drawPlaceCard dessine un marque-place plié en deux : le nom figure sur
+// les deux faces, la face du haut est retournée pour être lisible une fois
+// la carte pliée
+func drawPlaceCard(pdf *gofpdf.Fpdf, card PrintCard, x, y float64) {
+	half := placeCardHeight / 2
+
+	pdf.SetDrawColor(210, 210, 210)
+	pdf.SetDashPattern([]float64{3, 2}, 0)
+	pdf.Line(x, y+half, x+placeCardWidth, y+half)
+	pdf.SetDashPattern([]float64{}, 0)
+
+	face := func(top float64) {
+		pdf.SetTextColor(40, 40, 40)
+		fitFont(pdf, printFont, "I", 20, card.Name, placeCardWidth-10)
+		pdf.SetXY(x+5, top+12)
+		pdf.CellFormat(placeCardWidth-10, 10, card.Name, "", 0, "C", false, 0, "")
+		pdf.SetFont(printFont, "", 9)
+		pdf.SetXY(x+5, top+28)
+		pdf.CellFormat(placeCardWidth-10, 5, card.Table, "", 0, "C", false, 0, "")
+	}
+
+	face(y + half)
+
+	pdf.TransformBegin()
+	pdf.TransformRotate(180, x+placeCardWidth/2, y+half/2)
+	face(y)
+	pdf.TransformEnd()
+}
+
+// tablePlanPDF dessine le plan de table grand format : une case par table
+// avec les foyers qui y sont placés
+func tablePlanPDF(plan *SeatingPlan, t *i18n.Translations) *gofpdf.Fpdf {
+	const (
+		margin  = 20.0
+		cols    = 4
+		gap     = 10.0
+		lineH   = 8.0
+		headerH = 16.0
+	)
+	pageWidth, pageHeight := tablePlanSize.Wd, tablePlanSize.Ht
+	boxWidth := (pageWidth - 2*margin - (cols-1)*gap) / cols
+
+	pdf := newPDF("L", tablePlanSize, t.T("print.table_plan"))
+
+	title := func() float64 {
+		pdf.AddPage()
+		pdf.SetTextColor(40, 40, 40)
+		pdf.SetFont(printFont, "I", 40)
+		pdf.SetXY(margin, margin)
+		pdf.CellFormat(pageWidth-2*margin, 18, t.T("print.table_plan"), "", 0, "C", false, 0, "")
+		return margin + 30
+	}
+
+	y := title()
+	for start := 0; start < len(plan.Tables); start += cols {
+		row := plan.Tables[start:min(start+cols, len(plan.Tables))]
+
+		// Foyers de chaque table de la rangée, réponses supprimées ignorées
+		labels := make([][]string, len(row))
+		lines := 0
+		for i, table := range row {
+			for _, party := range table.Parties {
+				if party.RSVP == nil {
+					continue
+				}
+				label := party.RSVP.FullName()
+				if persons := party.Adults + party.Children; persons > 1 {
+					label += fmt.Sprintf(" (%d)", persons)
+				}
+				labels[i] = append(labels[i], label)
+			}
+			lines = max(lines, len(labels[i]))
+		}
+
+		// Une rangée qui ne tient pas sous la précédente commence une
+		// nouvelle page ; plus haute qu'une page, ses tables inachevées se
+		// poursuivent sur les suivantes, sous leur nom répété
+		if y+headerH+float64(lines)*lineH+6 > pageHeight-margin && y > margin+30 {
+			y = title()
+		}
+		for offset := 0; ; {
+			count := min(lines-offset, int((pageHeight-margin-y-headerH-6)/lineH))
+			height := headerH + float64(count)*lineH + 6
+
+			for i, table := range row {
+				if offset > 0 && offset >= len(labels[i]) {
+					continue // Table terminée sur la page précédente
+				}
+				x := margin + float64(i)*(boxWidth+gap)
+
+				pdf.SetDrawColor(120, 120, 120)
+				pdf.SetLineWidth(0.5)
+				pdf.Rect(x, y, boxWidth, height, "D")
+
+				fitFont(pdf, printFont, "B", 18, table.Name, boxWidth-8)
+				pdf.SetXY(x+4, y+4)
+				pdf.CellFormat(boxWidth-8, 10, table.Name, "", 0, "C", false, 0, "")
+
+				pdf.SetFont(printFont, "", 13)
+				for n, label := range labels[i][offset:min(offset+count, len(labels[i]))] {
+					pdf.SetXY(x+6, y+headerH+float64(n)*lineH)
+					pdf.CellFormat(boxWidth-12, lineH, label, "", 0, "L", false, 0, "")
+				}
+			}
+
+			y += height + gap
+			offset += count
+			if offset >= lines {
+				break
+			}
+			y = title()
+		}
+	}
+	return pdf
+}
+
+// fitFont choisit la police à la taille donnée, réduite si nécessaire pour
+// que le texte tienne dans la largeur disponible
+func fitFont(pdf *gofpdf.Fpdf, family, style string, size float64, text string, width float64) {
+	pdf.SetFont(family, style, size)
+	for size > 6 && pdf.GetStringWidth(text) > width {
+		size--
+		pdf.SetFont(family, style, size)
+	}
+}
@@ -0,0 +1,145 @@
+package application
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/domain/ports"
+)
+
+var (
+	ErrRegistryItemNotFound = errors.New("cadeau introuvable")
+)
+
+// RegistryService gère la liste de mariage, la cagnotte et les réservations
+type RegistryService struct {
+	storage ports.RegistryStorage
+	mu      sync.Mutex // Sérialise les réservations : un cadeau n'est réservé qu'une fois
+}
+
+// NewRegistryService crée un nouveau service de liste de mariage
+func NewRegistryService(storage ports.RegistryStorage) *RegistryService {
+	return &RegistryService{
+		storage: storage,
+	}
+}
+
+// ListItems retourne les cadeaux dans l'ordre de création
+func (s *RegistryService) ListItems() ([]*domain.RegistryItem, error) {
+	items, err := s.storage.FindAll()
+	if err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	sorted := make([]*domain.RegistryItem, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+	return sorted, nil
+}
+
+// AddItem ajoute un cadeau ou une cagnotte (administration)
+func (s *RegistryService) AddItem(title, description, imageURL string, price int, fund bool) (*domain.RegistryItem, error) {
+	item, err := domain.NewRegistryItem(title, description, imageURL, price, fund)
+	if err != nil {
+		return nil, err
+	}
+
+	item.ID = generateID()
+
+	if err := s.storage.Save(item); err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	return item, nil
+}
+
+// DeleteItem supprime un cadeau et ses réservations (administration)
+func (s *RegistryService) DeleteItem(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.storage.Delete(id); err != nil {
+		return ErrStorageFailure
+	}
+	return nil
+}
+
+// Reserve enregistre l'engagement d'un invité à offrir un cadeau, ou sa
+// participation à une cagnotte
+func (s *RegistryService) Reserve(invitation *domain.Invitation, itemID, name string, anonymous bool, amount int) (*domain.GiftReservation, error) {
+	reservation, err := domain.NewGiftReservation(name, anonymous, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation.ID = generateID()
+	if invitation != nil {
+		reservation.InvitationID = invitation.ID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, err := s.storage.FindByID(itemID)
+	if err != nil {
+		return nil, ErrRegistryItemNotFound
+	}
+
+	if err := item.Reserve(*reservation); err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.Save(item); err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	// La réservation enregistrée porte le prix d'un cadeau à montant fixe
+	reserved := item.Reservations[len(item.Reservations)-1]
+	return &reserved, nil
+}
+
+// ReleaseOwn annule une réservation faite par le foyer invité
+func (s *RegistryService) ReleaseOwn(invitation *domain.Invitation, itemID, reservationID string) error {
+	if invitation == nil {
+		return ErrInvitationRequired
+	}
+	return s.release(itemID, reservationID, func(reservation domain.GiftReservation) bool {
+		return reservation.InvitationID == invitation.ID
+	})
+}
+
+// ReleaseReservation annule n'importe quelle réservation (administration)
+func (s *RegistryService) ReleaseReservation(itemID, reservationID string) error {
+	return s.release(itemID, reservationID, func(domain.GiftReservation) bool {
+		return true
+	})
+}
+
+// release annule une réservation si elle satisfait le prédicat d'autorisation
+func (s *RegistryService) release(itemID, reservationID string, allowed func(domain.GiftReservation) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, err := s.storage.FindByID(itemID)
+	if err != nil {
+		return ErrRegistryItemNotFound
+	}
+
+	for _, reservation := range item.Reservations {
+		if reservation.ID == reservationID && !allowed(reservation) {
+			return domain.ErrReservationNotFound
+		}
+	}
+
+	if err := item.Release(reservationID); err != nil {
+		return err
+	}
+
+	if err := s.storage.Save(item); err != nil {
+		return ErrStorageFailure
+	}
+	return nil
+}
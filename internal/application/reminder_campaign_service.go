@@ -0,0 +1,278 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/domain/ports"
+	"wedding-web/internal/i18n"
+)
+
+var (
+	ErrReminderCampaignNotFound = errors.New("campagne de relance introuvable")
+)
+
+// reminderPollInterval est la fréquence de vérification des campagnes à lancer
+const reminderPollInterval = 15 * time.Minute
+
+// ReminderCampaignStatus est l'état d'une campagne de relance, pour l'administration
+type ReminderCampaignStatus struct {
+	ID          string
+	DaysBefore  int
+	ScheduledAt time.Time
+	RunAt       time.Time // Dernier passage (zéro : jamais lancée)
+	SentCount   int       // Foyers déjà relancés par cette campagne
+	Pending     int       // Foyers sans réponse qu'elle relancerait maintenant
+	Missed      bool      // Date passée sans envoi, une campagne plus proche de la date limite ayant pris le relais
+	Expired     bool      // Jamais lancée, date limite de réponse dépassée
+}
+
+// ReminderCampaignService relance par email les invitations restées sans
+// réponse, aux dates prévues avant la date limite de réponse. Chaque
+// campagne ne relance un foyer qu'une fois ; seuls les foyers dont
+// l'adresse est connue sont relancés.
+type ReminderCampaignService struct {
+	storage             ports.ReminderCampaignStorage
+	invitationService   *InvitationService
+	rsvpService         *RSVPService
+	planningService     *PlanningService
+	notificationService *NotificationService
+	daysBefore          []int         // Campagnes, de la plus ancienne à la plus proche de la date limite
+	sendTime            time.Duration // Heure d'envoi des campagnes (depuis minuit)
+	baseURL             string
+	mu                  sync.Mutex // Une seule campagne envoyée à la fois
+}
+
+// NewReminderCampaignService crée le service des campagnes de relance,
+// envoyées daysBefore jours avant la date limite, à l'heure sendTime
+func NewReminderCampaignService(storage ports.ReminderCampaignStorage, invitationService *InvitationService, rsvpService *RSVPService, planningService *PlanningService, notificationService *NotificationService, daysBefore []int, sendTime time.Duration, baseURL string) *ReminderCampaignService {
+	days := append([]int(nil), daysBefore...)
+	sort.Sort(sort.Reverse(sort.IntSlice(days)))
+
+	return &ReminderCampaignService{
+		storage:             storage,
+		invitationService:   invitationService,
+		rsvpService:         rsvpService,
+		planningService:     planningService,
+		notificationService: notificationService,
+		daysBefore:          days,
+		sendTime:            sendTime,
+		baseURL:             baseURL,
+	}
+}
+
+// Campaigns retourne l'état des campagnes, dans l'ordre d'envoi
+func (s *ReminderCampaignService) Campaigns(now time.Time) ([]ReminderCampaignStatus, error) {
+	campaigns, err := s.campaigns()
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.nonResponders()
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := s.planningService.GetPlanning().RSVPDeadline
+	due := s.dueCampaign(campaigns, now)
+	statuses := make([]ReminderCampaignStatus, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		scheduledAt := campaign.ScheduledAt(deadline, s.sendTime)
+		status := ReminderCampaignStatus{
+			ID:          campaign.ID,
+			DaysBefore:  campaign.DaysBefore,
+			ScheduledAt: scheduledAt,
+			RunAt:       campaign.RunAt,
+			SentCount:   len(campaign.Sent),
+			Expired:     !campaign.HasRun() && !now.Before(deadline),
+		}
+		status.Missed = !campaign.HasRun() && !status.Expired && !scheduledAt.After(now) && campaign != due
+		for _, invitation := range pending {
+			if !campaign.SentTo(invitation.ID) {
+				status.Pending++
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// SendCampaign envoie tout de suite la campagne donnée aux foyers sans
+// réponse qu'elle n'a pas encore relancés. Retourne le nombre de relances.
+func (s *ReminderCampaignService) SendCampaign(id string, now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	campaigns, err := s.campaigns()
+	if err != nil {
+		return 0, err
+	}
+	for _, campaign := range campaigns {
+		if campaign.ID == id {
+			return s.send(campaign, now)
+		}
+	}
+	return 0, ErrReminderCampaignNotFound
+}
+
+// ProcessDue lance la campagne prévue, si elle ne l'a pas encore été. Si
+// plusieurs dates sont passées (serveur arrêté), seule la campagne la plus
+// proche de la date limite part : les foyers ne reçoivent pas plusieurs
+// relances à la fois. Rien ne part après la date limite.
+func (s *ReminderCampaignService) ProcessDue(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	campaigns, err := s.campaigns()
+	if err != nil {
+		return err
+	}
+
+	campaign := s.dueCampaign(campaigns, now)
+	if campaign == nil || campaign.HasRun() {
+		return nil
+	}
+	sent, err := s.send(campaign, now)
+	if sent > 0 {
+		log.Printf("Relance %s envoyée à %d foyer(s)", campaign.ID, sent)
+	}
+	return err
+}
+
+// Run lance les campagnes à leur date jusqu'à l'annulation du contexte
+func (s *ReminderCampaignService) Run(ctx context.Context) {
+	ticker := time.NewTicker(reminderPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ProcessDue(time.Now()); err != nil {
+			log.Printf("Relances: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// send relance les foyers sans réponse que la campagne n'a pas encore
+// relancés, et enregistre les envois
+func (s *ReminderCampaignService) send(campaign *domain.ReminderCampaign, now time.Time) (int, error) {
+	pending, err := s.nonResponders()
+	if err != nil {
+		return 0, err
+	}
+
+	deadline := s.planningService.GetPlanning().RSVPDeadline
+	var errs []error
+	sent := 0
+	for _, invitation := range pending {
+		if campaign.SentTo(invitation.ID) {
+			continue
+		}
+		if err := s.notificationService.RemindInvitation(invitation, deadline, s.rsvpURL(invitation)); err != nil {
+			errs = append(errs, fmt.Errorf("relance de %q: %w", invitation.Name, err))
+			continue
+		}
+		campaign.MarkSent(invitation.ID, now)
+		sent++
+	}
+
+	campaign.RunAt = now
+	if err := s.storage.Save(campaign); err != nil {
+		errs = append(errs, ErrStorageFailure)
+	}
+	return sent, errors.Join(errs...)
+}
+
+// dueCampaign retourne la campagne la plus proche de la date limite dont
+// la date est passée, ou nil (aucune, ou date limite dépassée)
+func (s *ReminderCampaignService) dueCampaign(campaigns []*domain.ReminderCampaign, now time.Time) *domain.ReminderCampaign {
+	deadline := s.planningService.GetPlanning().RSVPDeadline
+	if !now.Before(deadline) {
+		return nil
+	}
+
+	var due *domain.ReminderCampaign
+	for _, campaign := range campaigns {
+		if !campaign.ScheduledAt(deadline, s.sendTime).After(now) {
+			due = campaign
+		}
+	}
+	return due
+}
+
+// campaigns retourne les campagnes configurées avec leurs envois
+// enregistrés, de la plus ancienne à la plus proche de la date limite
+func (s *ReminderCampaignService) campaigns() ([]*domain.ReminderCampaign, error) {
+	stored, err := s.storage.FindAll()
+	if err != nil {
+		return nil, ErrStorageFailure
+	}
+	byID := make(map[string]*domain.ReminderCampaign, len(stored))
+	for _, campaign := range stored {
+		byID[campaign.ID] = campaign
+	}
+
+	campaigns := make([]*domain.ReminderCampaign, 0, len(s.daysBefore))
+	for _, days := range s.daysBefore {
+		campaign, ok := byID[domain.ReminderCampaignID(days)]
+		if !ok {
+			campaign, err = domain.NewReminderCampaign(days)
+			if err != nil {
+				return nil, err
+			}
+		}
+		campaigns = append(campaigns, campaign)
+	}
+	return campaigns, nil
+}
+
+// nonResponders retourne les invitations sans réponse dont l'adresse est connue
+func (s *ReminderCampaignService) nonResponders() ([]*domain.Invitation, error) {
+	invitations, err := s.invitationService.ListInvitations()
+	if err != nil {
+		return nil, err
+	}
+	rsvps, err := s.rsvpService.ListRSVPs()
+	if err != nil {
+		return nil, err
+	}
+
+	answered := make(map[string]bool, len(rsvps))
+	for _, rsvp := range rsvps {
+		answered[rsvp.InvitationID] = true
+	}
+
+	var pending []*domain.Invitation
+	for _, invitation := range invitations {
+		if invitation.Email != "" && !answered[invitation.ID] {
+			pending = append(pending, invitation)
+		}
+	}
+	return pending, nil
+}
+
+// rsvpURL retourne le lien personnel du foyer vers le formulaire de
+// réponse, dans sa langue
+func (s *ReminderCampaignService) rsvpURL(invitation *domain.Invitation) string {
+	lang := i18n.Default
+	if invitation.Lang != "" {
+		lang = i18n.Lang(invitation.Lang)
+	}
+
+	link := s.baseURL + i18n.URL(lang, "/rsvp")
+	separator := "?"
+	if strings.Contains(link, "?") {
+		separator = "&"
+	}
+	return link + separator + "invite=" + url.QueryEscape(invitation.Code)
+}
@@ -2,39 +2,59 @@ package application
 
 import (
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
 	"errors"
+	"sort"
+	"strings"
+	"time"
 	"wedding-web/internal/domain"
 	"wedding-web/internal/domain/ports"
+	"wedding-web/internal/i18n"
 )
 
 var (
 	ErrStorageFailure = errors.New("erreur de stockage")
+	ErrNotAttending   = errors.New("présence non confirmée")
+	ErrRSVPNotFound   = errors.New("réponse introuvable")
 )
 
 // RSVPService gère la logique métier des RSVP
 type RSVPService struct {
-	storage ports.RSVPStorage
+	storage         ports.RSVPStorage
+	planningService *PlanningService // Fournit les nuits proposées dans la question hébergement
 }
 
 // NewRSVPService crée un nouveau service RSVP
-func NewRSVPService(storage ports.RSVPStorage) *RSVPService {
+func NewRSVPService(storage ports.RSVPStorage, planningService *PlanningService) *RSVPService {
 	return &RSVPService{
-		storage: storage,
+		storage:         storage,
+		planningService: planningService,
 	}
 }
 
-// SubmitRSVP enregistre un nouveau RSVP
-func (s *RSVPService) SubmitRSVP(firstName, lastName string, willAttend bool, adultsCount, childrenCount int, allergies, message, ipAddress string) (*domain.RSVP, error) {
+// AccommodationNights retourne les nuits proposées dans la question hébergement
+func (s *RSVPService) AccommodationNights() []time.Time {
+	return s.planningService.GetPlanning().AccommodationNights()
+}
+
+// SubmitRSVP enregistre un nouveau RSVP, rattaché à l'invitation si elle est connue
+// Les nuits d'hébergement sont au format domain.NightDateFormat (vide: pas de besoin).
+// La langue de l'invité est mémorisée pour les documents et messages qui lui
+// sont destinés (langue par défaut si elle n'est pas disponible).
+func (s *RSVPService) SubmitRSVP(invitation *domain.Invitation, firstName, lastName string, willAttend bool, adultsCount, childrenCount int, allergies, message, email string, accommodationNights []string, ipAddress string, lang i18n.Lang) (*domain.RSVP, error) {
 	// Création et validation
-	rsvp, err := domain.NewRSVP(firstName, lastName, willAttend, adultsCount, childrenCount, allergies, message)
+	rsvp, err := s.newRSVP(firstName, lastName, willAttend, adultsCount, childrenCount, allergies, message, email, accommodationNights, ipAddress, lang)
 	if err != nil {
 		return nil, err
 	}
 
-	// Génération d'un ID unique
+	// Génération d'un ID unique et du secret du lien de modification
 	rsvp.ID = generateID()
-	rsvp.IPAddress = ipAddress
+	rsvp.EditToken = generateID()
+	if invitation != nil {
+		rsvp.InvitationID = invitation.ID
+	}
 
 	// Sauvegarde
 	if err := s.storage.Save(rsvp); err != nil {
@@ -44,6 +64,68 @@ func (s *RSVPService) SubmitRSVP(firstName, lastName string, willAttend bool, ad
 	return rsvp, nil
 }
 
+// UpdateRSVP remplace la réponse du lien de modification donné, qui garde
+// son ID et son invitation
+func (s *RSVPService) UpdateRSVP(editToken, firstName, lastName string, willAttend bool, adultsCount, childrenCount int, allergies, message, email string, accommodationNights []string, ipAddress string, lang i18n.Lang) (*domain.RSVP, error) {
+	previous, err := s.GetRSVPByEditToken(editToken)
+	if err != nil {
+		return nil, err
+	}
+
+	rsvp, err := s.newRSVP(firstName, lastName, willAttend, adultsCount, childrenCount, allergies, message, email, accommodationNights, ipAddress, lang)
+	if err != nil {
+		return nil, err
+	}
+	rsvp.ID = previous.ID
+	rsvp.EditToken = previous.EditToken
+	rsvp.InvitationID = previous.InvitationID
+
+	if err := s.storage.Save(rsvp); err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	return rsvp, nil
+}
+
+// GetRSVPByEditToken retourne la réponse correspondant à un lien de modification
+func (s *RSVPService) GetRSVPByEditToken(editToken string) (*domain.RSVP, error) {
+	if editToken == "" {
+		return nil, ErrRSVPNotFound
+	}
+
+	rsvps, err := s.storage.FindAll()
+	if err != nil {
+		return nil, ErrStorageFailure
+	}
+	for _, rsvp := range rsvps {
+		if subtle.ConstantTimeCompare([]byte(rsvp.EditToken), []byte(editToken)) == 1 {
+			return rsvp, nil
+		}
+	}
+	return nil, ErrRSVPNotFound
+}
+
+// newRSVP crée et valide une réponse, sans l'enregistrer
+func (s *RSVPService) newRSVP(firstName, lastName string, willAttend bool, adultsCount, childrenCount int, allergies, message, email string, accommodationNights []string, ipAddress string, lang i18n.Lang) (*domain.RSVP, error) {
+	rsvp, err := domain.NewRSVP(firstName, lastName, willAttend, adultsCount, childrenCount, allergies, message)
+	if err != nil {
+		return nil, err
+	}
+	if err := rsvp.SetAccommodationNights(accommodationNights, s.AccommodationNights()); err != nil {
+		return nil, err
+	}
+	if err := rsvp.SetEmail(email); err != nil {
+		return nil, err
+	}
+
+	rsvp.IPAddress = ipAddress
+	rsvp.Lang = string(i18n.Default)
+	if parsed, ok := i18n.Parse(string(lang)); ok {
+		rsvp.Lang = string(parsed)
+	}
+	return rsvp, nil
+}
+
 // ListRSVPs retourne tous les RSVP
 func (s *RSVPService) ListRSVPs() ([]*domain.RSVP, error) {
 	rsvps, err := s.storage.FindAll()
@@ -53,6 +135,79 @@ func (s *RSVPService) ListRSVPs() ([]*domain.RSVP, error) {
 	return rsvps, nil
 }
 
+// IsAttending indique si le foyer invité a confirmé sa présence dans sa dernière réponse
+func (s *RSVPService) IsAttending(invitation *domain.Invitation) (bool, error) {
+	if invitation == nil {
+		return false, nil
+	}
+
+	rsvps, err := s.storage.FindAll()
+	if err != nil {
+		return false, ErrStorageFailure
+	}
+
+	var latest *domain.RSVP
+	for _, rsvp := range rsvps {
+		if rsvp.InvitationID == invitation.ID && (latest == nil || !rsvp.SubmittedAt.Before(latest.SubmittedAt)) {
+			latest = rsvp
+		}
+	}
+	return latest != nil && latest.WillAttend, nil
+}
+
+// LatestRSVPs retourne la réponse de chaque foyer, triée par nom : la
+// dernière réponse de chaque invitation, et chaque réponse faite sans lien
+// personnel
+func (s *RSVPService) LatestRSVPs() ([]*domain.RSVP, error) {
+	rsvps, err := s.storage.FindAll()
+	if err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	latest := map[string]*domain.RSVP{}
+	var parties []*domain.RSVP
+	for _, rsvp := range rsvps {
+		if rsvp.InvitationID == "" {
+			parties = append(parties, rsvp)
+			continue
+		}
+		if previous, ok := latest[rsvp.InvitationID]; !ok || !rsvp.SubmittedAt.Before(previous.SubmittedAt) {
+			latest[rsvp.InvitationID] = rsvp
+		}
+	}
+	for _, rsvp := range latest {
+		parties = append(parties, rsvp)
+	}
+
+	sort.Slice(parties, func(i, j int) bool {
+		a, b := parties[i], parties[j]
+		if !strings.EqualFold(a.LastName, b.LastName) {
+			return strings.ToLower(a.LastName) < strings.ToLower(b.LastName)
+		}
+		if !strings.EqualFold(a.FirstName, b.FirstName) {
+			return strings.ToLower(a.FirstName) < strings.ToLower(b.FirstName)
+		}
+		return a.ID < b.ID
+	})
+	return parties, nil
+}
+
+// AttendingRSVPs retourne les foyers présents, triés par nom (voir LatestRSVPs)
+func (s *RSVPService) AttendingRSVPs() ([]*domain.RSVP, error) {
+	parties, err := s.LatestRSVPs()
+	if err != nil {
+		return nil, err
+	}
+
+	attending := make([]*domain.RSVP, 0, len(parties))
+	for _, rsvp := range parties {
+		if rsvp.WillAttend {
+			attending = append(attending, rsvp)
+		}
+	}
+	return attending, nil
+}
+
 // DeleteRSVP supprime un RSVP par son ID
 func (s *RSVPService) DeleteRSVP(id string) error {
 	err := s.storage.Delete(id)
@@ -0,0 +1,263 @@
+package application
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/domain/ports"
+)
+
+var (
+	ErrTableNotFound = errors.New("table introuvable")
+)
+
+// SeatedParty est un foyer (ou la partie d'un foyer) placé à une table,
+// ou restant à placer
+type SeatedParty struct {
+	RSVP     *domain.RSVP // nil si la réponse a été supprimée
+	Adults   int
+	Children int
+}
+
+// TableView est une table avec les foyers qui y sont placés
+type TableView struct {
+	*domain.Table
+	Parties []SeatedParty
+}
+
+// SeatingPlanWarning est un avertissement du plan de table, avec le nom du foyer concerné
+type SeatingPlanWarning struct {
+	domain.SeatingWarning
+	Guest string
+}
+
+// SeatingPlan est le plan de table complet : tables, foyers restant à
+// placer et contraintes non respectées
+type SeatingPlan struct {
+	Tables    []TableView
+	Unseated  []SeatedParty // Personnes de chaque foyer présent pas encore placées
+	Warnings  []SeatingPlanWarning
+	Attending int // Nombre de personnes présentes
+	Seated    int // Nombre de personnes présentes placées
+	Capacity  int // Nombre total de places
+}
+
+// SeatingService gère le plan de table des invités présents
+type SeatingService struct {
+	storage     ports.SeatingStorage
+	rsvpService *RSVPService
+	mu          sync.Mutex // Sérialise les placements (vérification puis enregistrement)
+}
+
+// NewSeatingService crée un nouveau service de plan de table
+func NewSeatingService(storage ports.SeatingStorage, rsvpService *RSVPService) *SeatingService {
+	return &SeatingService{
+		storage:     storage,
+		rsvpService: rsvpService,
+	}
+}
+
+// ListTables retourne les tables dans l'ordre de création
+func (s *SeatingService) ListTables() ([]*domain.Table, error) {
+	tables, err := s.storage.FindAll()
+	if err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	sorted := make([]*domain.Table, len(tables))
+	copy(sorted, tables)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+	return sorted, nil
+}
+
+// AddTable ajoute une table
+func (s *SeatingService) AddTable(name string, capacity int) (*domain.Table, error) {
+	table, err := domain.NewTable(name, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	table.ID = generateID()
+
+	if err := s.storage.Save(table); err != nil {
+		return nil, ErrStorageFailure
+	}
+	return table, nil
+}
+
+// UpdateTable renomme une table ou change sa capacité
+func (s *SeatingService) UpdateTable(id, name string, capacity int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	table, err := s.storage.FindByID(id)
+	if err != nil {
+		return ErrTableNotFound
+	}
+
+	if err := table.Update(name, capacity); err != nil {
+		return err
+	}
+
+	if err := s.storage.Save(table); err != nil {
+		return ErrStorageFailure
+	}
+	return nil
+}
+
+// DeleteTable supprime une table (ses invités redeviennent à placer)
+func (s *SeatingService) DeleteTable(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.storage.Delete(id); err != nil {
+		return ErrTableNotFound
+	}
+	return nil
+}
+
+// Assign place des adultes et des enfants d'un foyer présent à une table
+// (0 et 0 retirent le foyer de la table). Un foyer ne peut pas être placé
+// au-delà du nombre de personnes annoncé dans sa réponse.
+func (s *SeatingService) Assign(tableID, rsvpID string, adults, children int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	party, err := s.attendingParty(rsvpID)
+	if err != nil {
+		return err
+	}
+
+	tables, err := s.storage.FindAll()
+	if err != nil {
+		return ErrStorageFailure
+	}
+
+	var target *domain.Table
+	otherAdults, otherChildren := 0, 0
+	for _, table := range tables {
+		if table.ID == tableID {
+			target = table
+			continue
+		}
+		a, c := table.Seated(rsvpID)
+		otherAdults += a
+		otherChildren += c
+	}
+	if target == nil {
+		return ErrTableNotFound
+	}
+
+	if otherAdults+adults > party.AdultsCount || otherChildren+children > party.ChildrenCount {
+		return domain.ErrInvalidSeating
+	}
+
+	if err := target.Assign(rsvpID, adults, children); err != nil {
+		return err
+	}
+
+	if err := s.storage.Save(target); err != nil {
+		return ErrStorageFailure
+	}
+	return nil
+}
+
+// Unassign retire un foyer d'une table
+func (s *SeatingService) Unassign(tableID, rsvpID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	table, err := s.storage.FindByID(tableID)
+	if err != nil {
+		return ErrTableNotFound
+	}
+
+	table.Unassign(rsvpID)
+
+	if err := s.storage.Save(table); err != nil {
+		return ErrStorageFailure
+	}
+	return nil
+}
+
+// Plan construit le plan de table : foyers de chaque table, personnes
+// restant à placer et avertissements
+func (s *SeatingService) Plan() (*SeatingPlan, error) {
+	tables, err := s.ListTables()
+	if err != nil {
+		return nil, err
+	}
+	attending, err := s.rsvpService.AttendingRSVPs()
+	if err != nil {
+		return nil, err
+	}
+	// Toutes les réponses, pour nommer les foyers placés qui ne viennent plus
+	all, err := s.rsvpService.ListRSVPs()
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*domain.RSVP, len(all))
+	for _, rsvp := range all {
+		byID[rsvp.ID] = rsvp
+	}
+	present := make(map[string]bool, len(attending))
+	for _, rsvp := range attending {
+		present[rsvp.ID] = true
+	}
+
+	plan := &SeatingPlan{}
+	seatedAdults := map[string]int{}
+	seatedChildren := map[string]int{}
+
+	for _, table := range tables {
+		view := TableView{Table: table}
+		for _, seat := range table.Seats {
+			view.Parties = append(view.Parties, SeatedParty{RSVP: byID[seat.RSVPID], Adults: seat.Adults, Children: seat.Children})
+			if present[seat.RSVPID] {
+				seatedAdults[seat.RSVPID] += seat.Adults
+				seatedChildren[seat.RSVPID] += seat.Children
+				plan.Seated += seat.Adults + seat.Children
+			}
+		}
+		plan.Tables = append(plan.Tables, view)
+		plan.Capacity += table.Capacity
+	}
+
+	for _, rsvp := range attending {
+		plan.Attending += rsvp.TotalGuests()
+
+		adults := max(rsvp.AdultsCount-seatedAdults[rsvp.ID], 0)
+		children := max(rsvp.ChildrenCount-seatedChildren[rsvp.ID], 0)
+		if adults+children > 0 {
+			plan.Unseated = append(plan.Unseated, SeatedParty{RSVP: rsvp, Adults: adults, Children: children})
+		}
+	}
+
+	for _, warning := range domain.CheckSeating(tables, attending) {
+		guest := ""
+		if rsvp := byID[warning.RSVPID]; rsvp != nil {
+			guest = rsvp.FullName()
+		}
+		plan.Warnings = append(plan.Warnings, SeatingPlanWarning{SeatingWarning: warning, Guest: guest})
+	}
+
+	return plan, nil
+}
+
+// attendingParty retourne la réponse d'un foyer présent
+func (s *SeatingService) attendingParty(rsvpID string) (*domain.RSVP, error) {
+	attending, err := s.rsvpService.AttendingRSVPs()
+	if err != nil {
+		return nil, err
+	}
+	for _, rsvp := range attending {
+		if rsvp.ID == rsvpID {
+			return rsvp, nil
+		}
+	}
+	return nil, domain.ErrInvalidSeating
+}
@@ -1,8 +1,19 @@
 package application
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf16"
 	"wedding-web/internal/domain"
+	"wedding-web/internal/i18n"
 )
 
 // Mock storage pour les tests
@@ -15,6 +26,12 @@ func (m *mockStorage) Save(rsvp *domain.RSVP) error {
 	if m.err != nil {
 		return m.err
 	}
+	for i, existing := range m.rsvps {
+		if existing.ID == rsvp.ID {
+			m.rsvps[i] = rsvp
+			return nil
+		}
+	}
 	m.rsvps = append(m.rsvps, rsvp)
 	return nil
 }
@@ -53,9 +70,9 @@ func (m *mockStorage) Delete(id string) error {
 
 func TestRSVPService_SubmitRSVP(t *testing.T) {
 	storage := &mockStorage{rsvps: []*domain.RSVP{}}
-	service := NewRSVPService(storage)
+	service := NewRSVPService(storage, NewPlanningService())
 
-	rsvp, err := service.SubmitRSVP("Jean", "Dupont", true, 2, 1, "Aucune", "Message", "127.0.0.1")
+	rsvp, err := service.SubmitRSVP(nil, "Jean", "Dupont", true, 2, 1, "Aucune", "Message", "", nil, "127.0.0.1", i18n.FR)
 
 	if err != nil {
 		t.Fatalf("SubmitRSVP() error = %v", err)
@@ -73,31 +90,87 @@ func TestRSVPService_SubmitRSVP(t *testing.T) {
 		t.Errorf("FirstName = %s, want Jean", rsvp.FirstName)
 	}
 
-	// Vérifier que le RSVP a été sauvegardé
-	if len(storage.rsvps) != 1 {
-		t.Errorf("Storage contains %d RSVPs, want 1", len(storage.rsvps))
+	if rsvp.Lang != "fr" {
+		t.Errorf("Lang = %s, want fr", rsvp.Lang)
+	}
+
+	// Une langue indisponible est remplacée par la langue par défaut
+	other, err := service.SubmitRSVP(nil, "Anna", "Müller", false, 0, 0, "", "", "", nil, "127.0.0.1", i18n.DE)
+	if err != nil || other.Lang != "de" {
+		t.Errorf("SubmitRSVP(de) = %+v, %v, want lang de", other, err)
+	}
+	if other, _ := service.SubmitRSVP(nil, "Anna", "Müller", false, 0, 0, "", "", "", nil, "127.0.0.1", "xx"); other.Lang != "fr" {
+		t.Errorf("SubmitRSVP(xx).Lang = %s, want fr", other.Lang)
+	}
+
+	// Vérifier que les RSVP ont été sauvegardés
+	if len(storage.rsvps) != 3 {
+		t.Errorf("Storage contains %d RSVPs, want 3", len(storage.rsvps))
 	}
 }
 
 func TestRSVPService_SubmitRSVP_Invalid(t *testing.T) {
 	storage := &mockStorage{rsvps: []*domain.RSVP{}}
-	service := NewRSVPService(storage)
+	service := NewRSVPService(storage, NewPlanningService())
 
 	// Test avec des données invalides
-	_, err := service.SubmitRSVP("", "Dupont", true, 1, 0, "", "", "127.0.0.1")
+	_, err := service.SubmitRSVP(nil, "", "Dupont", true, 1, 0, "", "", "", nil, "127.0.0.1", i18n.FR)
 
 	if err == nil {
 		t.Error("SubmitRSVP() should return an error for invalid data")
 	}
 }
 
+func TestRSVPService_UpdateRSVP(t *testing.T) {
+	storage := &mockStorage{rsvps: []*domain.RSVP{}}
+	service := NewRSVPService(storage, NewPlanningService())
+
+	invitation := &domain.Invitation{ID: "inv-1"}
+	rsvp, err := service.SubmitRSVP(invitation, "Jean", "Dupont", true, 2, 0, "", "", "jean@example.com", nil, "127.0.0.1", i18n.FR)
+	if err != nil {
+		t.Fatalf("SubmitRSVP() error = %v", err)
+	}
+	if rsvp.EditToken == "" || rsvp.EditToken == rsvp.ID {
+		t.Fatalf("EditToken = %q, want a secret distinct from the ID", rsvp.EditToken)
+	}
+
+	found, err := service.GetRSVPByEditToken(rsvp.EditToken)
+	if err != nil || found.ID != rsvp.ID {
+		t.Fatalf("GetRSVPByEditToken() = %v, %v", found, err)
+	}
+	for _, token := range []string{"", "inconnu"} {
+		if _, err := service.GetRSVPByEditToken(token); !errors.Is(err, ErrRSVPNotFound) {
+			t.Errorf("GetRSVPByEditToken(%q) error = %v, want ErrRSVPNotFound", token, err)
+		}
+	}
+
+	// La modification remplace la réponse, qui garde son ID et son invitation
+	updated, err := service.UpdateRSVP(rsvp.EditToken, "Jean", "Dupont", false, 0, 0, "", "Désolé", "", nil, "127.0.0.1", i18n.DE)
+	if err != nil {
+		t.Fatalf("UpdateRSVP() error = %v", err)
+	}
+	if updated.ID != rsvp.ID || updated.EditToken != rsvp.EditToken || updated.InvitationID != "inv-1" {
+		t.Errorf("UpdateRSVP() = %+v, want the same answer", updated)
+	}
+	if len(storage.rsvps) != 1 || storage.rsvps[0].WillAttend || storage.rsvps[0].Email != "" || storage.rsvps[0].Lang != "de" {
+		t.Errorf("stored = %+v, want the updated answer only", storage.rsvps)
+	}
+
+	if _, err := service.UpdateRSVP("inconnu", "Jean", "Dupont", true, 1, 0, "", "", "", nil, "", i18n.FR); !errors.Is(err, ErrRSVPNotFound) {
+		t.Errorf("UpdateRSVP() with unknown token error = %v, want ErrRSVPNotFound", err)
+	}
+	if _, err := service.UpdateRSVP(rsvp.EditToken, "Jean", "Dupont", true, 1, 0, "", "", "pas une adresse", nil, "", i18n.FR); !errors.Is(err, domain.ErrInvalidEmail) {
+		t.Errorf("UpdateRSVP() with invalid email error = %v, want ErrInvalidEmail", err)
+	}
+}
+
 func TestRSVPService_ListRSVPs(t *testing.T) {
 	storage := &mockStorage{rsvps: []*domain.RSVP{}}
-	service := NewRSVPService(storage)
+	service := NewRSVPService(storage, NewPlanningService())
 
 	// Ajouter quelques RSVPs
-	service.SubmitRSVP("Jean", "Dupont", true, 2, 0, "", "", "127.0.0.1")
-	service.SubmitRSVP("Marie", "Martin", true, 1, 1, "", "", "127.0.0.1")
+	service.SubmitRSVP(nil, "Jean", "Dupont", true, 2, 0, "", "", "", nil, "127.0.0.1", i18n.FR)
+	service.SubmitRSVP(nil, "Marie", "Martin", true, 1, 1, "", "", "", nil, "127.0.0.1", i18n.FR)
 
 	rsvps, err := service.ListRSVPs()
 
@@ -110,11 +183,333 @@ func TestRSVPService_ListRSVPs(t *testing.T) {
 	}
 }
 
+// Mock annuaire des hébergements pour les tests
+type mockAccommodationSource struct {
+	accommodations map[string][]domain.Accommodation
+	err            error
+}
+
+func (m *mockAccommodationSource) LoadAccommodations(lang string) ([]domain.Accommodation, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	accommodations, ok := m.accommodations[lang]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return accommodations, nil
+}
+
+func TestAccommodationService(t *testing.T) {
+	rsvpService := NewRSVPService(&mockStorage{rsvps: []*domain.RSVP{}}, NewPlanningService())
+	source := &mockAccommodationSource{accommodations: map[string][]domain.Accommodation{
+		"fr": {
+			{Name: "Hôtel de Londres", RoomsBlocked: 8},
+			{Name: "Gîte", RoomsBlocked: 2},
+		},
+		"de": {
+			{Name: "Hôtel de Londres", Kind: "Hotel", RoomsBlocked: 8},
+		},
+	}}
+	service := NewAccommodationService(source, rsvpService)
+
+	if _, err := rsvpService.SubmitRSVP(nil, "Jean", "Dupont", true, 2, 1, "", "", "", []string{"2026-07-10", "2026-07-11"}, "127.0.0.1", i18n.FR); err != nil {
+		t.Fatalf("SubmitRSVP() error = %v", err)
+	}
+	rsvpService.SubmitRSVP(nil, "Marie", "Martin", true, 2, 0, "", "", "", []string{"2026-07-11"}, "127.0.0.1", i18n.FR)
+	rsvpService.SubmitRSVP(nil, "Paul", "Durand", true, 1, 0, "", "", "", nil, "127.0.0.1", i18n.FR)
+
+	if _, err := rsvpService.SubmitRSVP(nil, "Luc", "Petit", true, 1, 0, "", "", "", []string{"2027-01-01"}, "127.0.0.1", i18n.FR); err != domain.ErrInvalidNights {
+		t.Errorf("SubmitRSVP() error = %v, want %v", err, domain.ErrInvalidNights)
+	}
+
+	demand, err := service.Demand()
+	if err != nil {
+		t.Fatalf("Demand() error = %v", err)
+	}
+	if demand.Households != 2 || demand.Guests != 5 {
+		t.Errorf("Demand() = %d households / %d guests, want 2 / 5", demand.Households, demand.Guests)
+	}
+	if len(demand.Nights) != 3 {
+		t.Fatalf("Demand() returned %d nights, want 3", len(demand.Nights))
+	}
+	if demand.Nights[1].Households != 2 || demand.Nights[1].Guests != 5 || demand.Nights[2].Households != 0 {
+		t.Errorf("Demand() nights = %+v", demand.Nights)
+	}
+	if demand.PeakHouseholds() != 2 {
+		t.Errorf("PeakHouseholds() = %d, want 2", demand.PeakHouseholds())
+	}
+
+	accommodations, err := service.ListAccommodations(i18n.FR)
+	if err != nil {
+		t.Fatalf("ListAccommodations() error = %v", err)
+	}
+	if service.RoomsBlocked(accommodations) != 10 {
+		t.Errorf("RoomsBlocked() = %d, want 10", service.RoomsBlocked(accommodations))
+	}
+
+	// Annuaire traduit, sinon version française
+	if accommodations, _ := service.ListAccommodations(i18n.DE); len(accommodations) != 1 || accommodations[0].Kind != "Hotel" {
+		t.Errorf("ListAccommodations(de) = %+v", accommodations)
+	}
+	delete(source.accommodations, "de")
+	if accommodations, _ := service.ListAccommodations(i18n.DE); len(accommodations) != 2 {
+		t.Errorf("ListAccommodations(de) without translation = %+v, want french version", accommodations)
+	}
+
+	// L'annuaire est facultatif
+	source.accommodations, source.err = nil, os.ErrNotExist
+	if accommodations, err := service.ListAccommodations(i18n.FR); err != nil || accommodations != nil {
+		t.Errorf("ListAccommodations() without file = %v, %v", accommodations, err)
+	}
+
+	// L'export contient la feuille hébergement
+	f, err := NewExportService(rsvpService, NewSeatingService(newMockSeatingStorage(), rsvpService)).ExportRSVPsToExcel()
+	if err != nil {
+		t.Fatalf("ExportRSVPsToExcel() error = %v", err)
+	}
+	value, _ := f.GetCellValue("Hébergement", "D2")
+	if value != "X" {
+		t.Errorf("Hébergement!D2 = %q, want X", value)
+	}
+	total, _ := f.GetCellValue("Hébergement", "E6")
+	if total != "5" {
+		t.Errorf("Hébergement!E6 = %q, want 5 guests on the wedding night", total)
+	}
+}
+
+// mockCollection est un storage en mémoire, pendant de encryptedCollection :
+// Save, FindAll, FindByID et Delete communs aux mocks de storage
+type mockCollection[T any] struct {
+	items    []*T
+	id       func(*T) string
+	notFound error
+}
+
+// newMockCollection crée un storage en mémoire contenant les éléments donnés
+func newMockCollection[T any](id func(*T) string, notFound error, items []*T) *mockCollection[T] {
+	return &mockCollection[T]{items: items, id: id, notFound: notFound}
+}
+
+func (m *mockCollection[T]) Save(item *T) error {
+	for i, existing := range m.items {
+		if m.id(existing) == m.id(item) {
+			m.items[i] = item
+			return nil
+		}
+	}
+	m.items = append(m.items, item)
+	return nil
+}
+
+func (m *mockCollection[T]) FindAll() ([]*T, error) {
+	return append([]*T(nil), m.items...), nil
+}
+
+func (m *mockCollection[T]) FindByID(id string) (*T, error) {
+	return m.find(func(item *T) bool { return m.id(item) == id })
+}
+
+func (m *mockCollection[T]) Delete(id string) error {
+	for i, item := range m.items {
+		if m.id(item) == id {
+			m.items = append(m.items[:i], m.items[i+1:]...)
+			return nil
+		}
+	}
+	return m.notFound
+}
+
+func (m *mockCollection[T]) find(match func(*T) bool) (*T, error) {
+	for _, item := range m.items {
+		if match(item) {
+			return item, nil
+		}
+	}
+	return nil, m.notFound
+}
+
+// Mock covoiturage pour les tests
+func newMockCarpoolStorage() *mockCollection[domain.CarpoolEntry] {
+	return newMockCollection(func(item *domain.CarpoolEntry) string { return item.ID }, ErrCarpoolEntryNotFound, nil)
+}
+
+func TestCarpoolService(t *testing.T) {
+	storage := newMockCarpoolStorage()
+	rsvpService := NewRSVPService(&mockStorage{}, NewPlanningService())
+	service := NewCarpoolService(storage, NewPlanningService(), rsvpService)
+
+	driver := &domain.Invitation{ID: "inv-1", Name: "Famille Dupont"}
+	guest := &domain.Invitation{ID: "inv-2", Name: "Famille Martin"}
+	other := &domain.Invitation{ID: "inv-3", Name: "Famille Durand"}
+	absent := &domain.Invitation{ID: "inv-4", Name: "Famille Petit"}
+	for _, invitation := range []*domain.Invitation{driver, guest, other} {
+		if _, err := rsvpService.SubmitRSVP(invitation, "Jean", "Dupont", true, 2, 0, "", "", "", nil, "127.0.0.1", i18n.FR); err != nil {
+			t.Fatalf("SubmitRSVP() error = %v", err)
+		}
+	}
+
+	legs := service.Legs(driver)
+	if len(legs) == 0 {
+		t.Fatal("Legs() returned no leg")
+	}
+	leg := legs[0].ID
+
+	if _, err := service.PostEntry(nil, "offer", leg, "Jean", "0612345678", 3, ""); err != ErrInvitationRequired {
+		t.Errorf("PostEntry() without invitation error = %v, want %v", err, ErrInvitationRequired)
+	}
+	// Seuls les foyers ayant confirmé leur présence publient
+	if _, err := service.PostEntry(absent, "offer", leg, "Luc", "0612345678", 3, ""); err != ErrNotAttending {
+		t.Errorf("PostEntry() without RSVP error = %v, want %v", err, ErrNotAttending)
+	}
+	rsvpService.SubmitRSVP(absent, "Luc", "Petit", false, 1, 0, "", "", "", nil, "127.0.0.1", i18n.FR)
+	if _, err := service.PostEntry(absent, "offer", leg, "Luc", "0612345678", 3, ""); err != ErrNotAttending {
+		t.Errorf("PostEntry() declined RSVP error = %v, want %v", err, ErrNotAttending)
+	}
+	if _, err := service.PostEntry(driver, "offer", "inconnu--trajet", "Jean", "0612345678", 3, ""); err != ErrUnknownCarpoolLeg {
+		t.Errorf("PostEntry() unknown leg error = %v, want %v", err, ErrUnknownCarpoolLeg)
+	}
+
+	offer, err := service.PostEntry(driver, "offer", leg, "Jean", "0612345678", 3, "")
+	if err != nil {
+		t.Fatalf("PostEntry() error = %v", err)
+	}
+	// Le foyer du conducteur n'est pas apparié à sa propre offre
+	own, _ := service.PostEntry(driver, "request", leg, "Jean", "0612345678", 1, "")
+	own.CreatedAt = offer.CreatedAt.Add(time.Second)
+	first, _ := service.PostEntry(guest, "request", leg, "Marie", "0698765432", 2, "")
+	first.CreatedAt = offer.CreatedAt.Add(2 * time.Second)
+	second, _ := service.PostEntry(other, "request", leg, "Paul", "0611111111", 2, "")
+	second.CreatedAt = offer.CreatedAt.Add(3 * time.Second)
+
+	board, err := service.Board(guest)
+	if err != nil {
+		t.Fatalf("Board() error = %v", err)
+	}
+	boardLeg := board[0]
+	if len(boardLeg.Matches) != 1 || len(boardLeg.Matches[0].Passengers) != 1 || boardLeg.Matches[0].Passengers[0] != first {
+		t.Fatalf("Board() matches = %+v, want Marie in Jean's car", boardLeg.Matches)
+	}
+	if boardLeg.Matches[0].SeatsLeft != 1 {
+		t.Errorf("SeatsLeft = %d, want 1", boardLeg.Matches[0].SeatsLeft)
+	}
+	if len(boardLeg.Unmatched) != 2 || boardLeg.ShuttleSeats() != 3 {
+		t.Errorf("Unmatched = %d entries / %d seats, want 2 / 3", len(boardLeg.Unmatched), boardLeg.ShuttleSeats())
+	}
+	if boardLeg.SeatsOffered != 3 || boardLeg.SeatsRequested != 5 {
+		t.Errorf("Seats offered/requested = %d/%d, want 3/5", boardLeg.SeatsOffered, boardLeg.SeatsRequested)
+	}
+
+	// Un foyer ne peut retirer que ses propres annonces
+	if err := service.WithdrawEntry(guest, offer.ID); err != ErrCarpoolEntryNotFound {
+		t.Errorf("WithdrawEntry() other household error = %v, want %v", err, ErrCarpoolEntryNotFound)
+	}
+	if err := service.WithdrawEntry(driver, offer.ID); err != nil {
+		t.Fatalf("WithdrawEntry() error = %v", err)
+	}
+	entries, _ := service.EntriesOf(driver)
+	if len(entries) != 1 {
+		t.Errorf("EntriesOf() = %d entries, want 1", len(entries))
+	}
+
+	// L'export liste les navettes à prévoir
+	overview, _ := service.Overview()
+	f, err := newTestExportService().ExportCarpoolToExcel(overview)
+	if err != nil {
+		t.Fatalf("ExportCarpoolToExcel() error = %v", err)
+	}
+	if index, _ := f.GetSheetIndex("Navettes"); index < 0 {
+		t.Error("ExportCarpoolToExcel() is missing the Navettes sheet")
+	}
+}
+
+// Mock suggestions musicales pour les tests
+func newMockSongStorage() *mockCollection[domain.SongSuggestion] {
+	return newMockCollection(func(item *domain.SongSuggestion) string { return item.ID }, ErrSongNotFound, nil)
+}
+
+func TestSongService(t *testing.T) {
+	storage := newMockSongStorage()
+	service := NewSongService(storage)
+
+	dupont := &domain.Invitation{ID: "inv-1", Name: "Famille Dupont"}
+	martin := &domain.Invitation{ID: "inv-2", Name: "Famille Martin"}
+
+	first, err := service.SuggestSong(dupont, "", "", "Alors on danse", "Stromae", "")
+	if err != nil {
+		t.Fatalf("SuggestSong() error = %v", err)
+	}
+	if first.SuggestedBy != "Famille Dupont" {
+		t.Errorf("SuggestedBy = %q, want the invitation name", first.SuggestedBy)
+	}
+
+	// Le même foyer ne vote qu'une fois pour un morceau
+	again, _ := service.SuggestSong(dupont, "", "", "alors on danse", "STROMAE", "")
+	if again != first || len(storage.items) != 1 {
+		t.Errorf("SuggestSong() duplicate stored %d suggestions, want 1", len(storage.items))
+	}
+
+	service.SuggestSong(dupont, "", "", "Dancing Queen", "ABBA", "")
+	service.SuggestSong(martin, "rsvp-1", "Marie Martin", "Alors on danse !", "Stromae", "https://www.youtube.com/watch?v=VHoT4N43jK8")
+	service.SuggestSong(nil, "", "", "Alors on danse", "Stromae", "")
+
+	if _, err := service.SuggestSong(nil, "", "", "", "ABBA", ""); err != domain.ErrInvalidSong {
+		t.Errorf("SuggestSong() error = %v, want %v", err, domain.ErrInvalidSong)
+	}
+
+	ranking, err := service.Ranking()
+	if err != nil {
+		t.Fatalf("Ranking() error = %v", err)
+	}
+	if len(ranking) != 2 {
+		t.Fatalf("Ranking() returned %d songs, want 2", len(ranking))
+	}
+	if ranking[0].Title != "Alors on danse" || ranking[0].Votes() != 3 || ranking[0].Rank != 1 {
+		t.Errorf("Ranking()[0] = %s (%d votes, rank %d), want Alors on danse with 3 votes", ranking[0].Title, ranking[0].Votes(), ranking[0].Rank)
+	}
+	if ranking[0].Link == "" {
+		t.Error("Ranking()[0] should keep the listening link of a later suggestion")
+	}
+
+	export := newTestExportService()
+
+	var csv strings.Builder
+	if err := export.ExportSongsToCSV(&csv, ranking); err != nil {
+		t.Fatalf("ExportSongsToCSV() error = %v", err)
+	}
+	if !strings.Contains(csv.String(), "1,Alors on danse,Stromae,https://www.youtube.com/watch?v=VHoT4N43jK8,3,\"Famille Dupont, Marie Martin\"") {
+		t.Errorf("ExportSongsToCSV() = %q", csv.String())
+	}
+
+	// Les cellules saisies par les invités ne sont pas exécutées par Excel
+	csv.Reset()
+	export.ExportSongsToCSV(&csv, []RankedSong{{Rank: 1, Title: "=HYPERLINK(\"https://evil.example\")", Artist: "@SUM(A1)", Suggestions: []*domain.SongSuggestion{{SuggestedBy: "-2+3"}}}})
+	if !strings.Contains(csv.String(), "1,\"'=HYPERLINK(\"\"https://evil.example\"\")\",'@SUM(A1),,1,'-2+3") {
+		t.Errorf("ExportSongsToCSV() = %q, want formulas neutralised", csv.String())
+	}
+
+	var m3u strings.Builder
+	if err := export.ExportSongsToM3U(&m3u, ranking); err != nil {
+		t.Fatalf("ExportSongsToM3U() error = %v", err)
+	}
+	want := "#EXTM3U\n#EXTINF:-1,Stromae - Alors on danse\nhttps://www.youtube.com/watch?v=VHoT4N43jK8\n#EXTINF:-1,ABBA - Dancing Queen\nABBA - Dancing Queen\n"
+	if m3u.String() != want {
+		t.Errorf("ExportSongsToM3U() = %q, want %q", m3u.String(), want)
+	}
+
+	if err := service.DeleteSong(ranking[0].Key); err != nil {
+		t.Fatalf("DeleteSong() error = %v", err)
+	}
+	if len(storage.items) != 1 {
+		t.Errorf("DeleteSong() left %d suggestions, want 1", len(storage.items))
+	}
+}
+
 func TestCalendarService_GenerateICS(t *testing.T) {
-	service := NewCalendarService()
+	service := NewCalendarService(nil)
 	planning := domain.GetDefaultPlanning()
 
-	icsData, err := service.GenerateICS(planning)
+	icsData, err := service.GenerateICS(planning, "fr")
 
 	if err != nil {
 		t.Fatalf("GenerateICS() error = %v", err)
@@ -140,6 +535,265 @@ func TestCalendarService_GenerateICS(t *testing.T) {
 	}
 }
 
+func TestCalendarService_TranslatedEvents(t *testing.T) {
+	service := NewCalendarService(nil)
+	planning := domain.GetDefaultPlanning()
+
+	french, _ := service.GenerateICS(planning, "fr")
+	german, _ := service.GenerateICS(planning, "de")
+
+	if !contains(string(german), "SUMMARY:Standesamtliche Trauung") || contains(string(german), "Cérémonie civile") {
+		t.Error("German ICS should use the German event titles")
+	}
+
+	// Les UID ne dépendent pas de la langue
+	uid := regexp.MustCompile(`UID:[^\r]+`)
+	if got, want := uid.FindAllString(string(german), -1), uid.FindAllString(string(french), -1); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("German UIDs = %v, want the French ones %v", got, want)
+	}
+}
+
+func TestCalendarService_GenerateFeed(t *testing.T) {
+	service := NewCalendarService(nil)
+	planning := &domain.Planning{
+		RSVPDeadline: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:    time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC),
+		Events: []domain.PlanningEvent{
+			{
+				Title:     "Cérémonie civile",
+				StartTime: time.Date(2026, 7, 11, 14, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2026, 7, 11, 15, 0, 0, 0, time.UTC),
+				Audience:  []string{"famille"},
+			},
+			{
+				Title:     "Vin d'honneur",
+				StartTime: time.Date(2026, 7, 11, 18, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2026, 7, 11, 20, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	// Flux public : uniquement les événements sans audience
+	public, err := service.GenerateFeed(planning, nil, "fr")
+	if err != nil {
+		t.Fatalf("GenerateFeed() error = %v", err)
+	}
+	if contains(string(public), "DTSTART;VALUE=DATE:20260301") {
+		t.Error("Public feed should not contain personal reminders")
+	}
+	if contains(string(public), "Cérémonie civile") {
+		t.Error("Public feed should not contain restricted events")
+	}
+	if !contains(string(public), "REFRESH-INTERVAL") {
+		t.Error("Feed missing REFRESH-INTERVAL")
+	}
+
+	// Flux personnel : événements du groupe et rappel de réponse
+	invitation := &domain.Invitation{ID: "inv-1", Name: "Famille Dupont", Groups: []string{"famille"}}
+	personal, err := service.GenerateFeed(planning, invitation, "fr")
+	if err != nil {
+		t.Fatalf("GenerateFeed() error = %v", err)
+	}
+	if !contains(string(personal), "Cérémonie civile") {
+		t.Error("Personal feed missing the family event")
+	}
+	if !contains(string(personal), "DTSTART;VALUE=DATE:20260301") {
+		t.Error("Personal feed missing the RSVP deadline reminder")
+	}
+
+	// Flux d'un autre foyer : sans les événements réservés à la famille
+	friends := &domain.Invitation{ID: "inv-2", Name: "Les amis", Groups: []string{"amis"}}
+	other, err := service.GenerateFeed(planning, friends, "fr")
+	if err != nil {
+		t.Fatalf("GenerateFeed() error = %v", err)
+	}
+	if contains(string(other), "Cérémonie civile") {
+		t.Error("Feed of another household should not contain the family event")
+	}
+	if !contains(string(other), "Vin d'honneur") {
+		t.Error("Feed of another household missing the public event")
+	}
+
+	// Le flux doit être stable d'une requête et d'un redémarrage à l'autre (ETag)
+	again, _ := NewCalendarService(nil).GenerateFeed(planning, invitation, "fr")
+	if string(again) != string(personal) {
+		t.Error("GenerateFeed() output is not stable")
+	}
+	if !service.LastModified(planning).Equal(planning.UpdatedAt) {
+		t.Errorf("LastModified() = %v, want the planning update date %v", service.LastModified(planning), planning.UpdatedAt)
+	}
+
+	// Le rappel de réponse suit la langue du flux
+	german, _ := service.GenerateFeed(planning, invitation, "de")
+	if !contains(string(german), "SUMMARY:Antwortfrist für die Hochzeit") || contains(string(german), "pensez à confirmer") {
+		t.Errorf("RSVP deadline reminder is not translated:\n%s", german)
+	}
+}
+
+func TestCalendarService_EventExport(t *testing.T) {
+	service := NewCalendarService(nil)
+	planning := domain.GetDefaultPlanning()
+
+	event, ok := planning.FindEvent("vin-honneur")
+	if !ok {
+		t.Fatal("FindEvent() did not find vin-honneur")
+	}
+
+	icsData, err := service.GenerateEventICS(planning, event, "fr")
+	if err != nil {
+		t.Fatalf("GenerateEventICS() error = %v", err)
+	}
+	if !contains(string(icsData), "SUMMARY:Vin d'honneur") {
+		t.Error("Single-event ICS missing the event")
+	}
+	if contains(string(icsData), "Cérémonie civile") {
+		t.Error("Single-event ICS contains other events")
+	}
+
+	google := service.GoogleCalendarURL(event)
+	if !contains(google, "dates=20260711T180000Z%2F20260711T200000Z") {
+		t.Errorf("GoogleCalendarURL() = %s, missing dates", google)
+	}
+
+	outlook := service.OutlookCalendarURL(event)
+	if !contains(outlook, "startdt=2026-07-11T18%3A00%3A00Z") {
+		t.Errorf("OutlookCalendarURL() = %s, missing startdt", outlook)
+	}
+
+	// Le lieu géolocalisé est exporté avec ses coordonnées
+	if !contains(string(icsData), "GEO:48.481030;2.595900") {
+		t.Error("Single-event ICS missing GEO property")
+	}
+	if !contains(string(icsData), "LOCATION:La Bergerie\\, Rue de la Bascule") {
+		t.Error("Single-event ICS missing venue location")
+	}
+
+	// Un événement sans horaire ne peut pas être exporté
+	photo, _ := planning.FindEvent("seance-photo")
+	if _, err := service.GenerateEventICS(planning, photo, "fr"); err != ErrEventNotSchedulable {
+		t.Errorf("GenerateEventICS() error = %v, want %v", err, ErrEventNotSchedulable)
+	}
+}
+
+func TestCalendarService_Reminders(t *testing.T) {
+	service := NewCalendarService([]time.Duration{24 * time.Hour, 2 * time.Hour})
+	planning := domain.GetDefaultPlanning()
+	event := domain.PlanningEvent{
+		ID:        "vin-honneur",
+		Title:     "Vin d'honneur",
+		StartTime: time.Date(2026, 7, 11, 18, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 7, 11, 20, 0, 0, 0, time.UTC),
+	}
+
+	// Rappels par défaut, description traduite
+	icsData, _ := service.GenerateEventICS(planning, event, "de")
+	ics := string(icsData)
+	if !contains(ics, "TRIGGER:-P1D") || !contains(ics, "TRIGGER:-PT2H") {
+		t.Errorf("Default reminders missing:\n%s", ics)
+	}
+	if !contains(ics, "DESCRIPTION:Erinnerung: Vin d'honneur") {
+		t.Error("Reminder description is not translated")
+	}
+
+	// Rappels propres à l'événement
+	event.Reminders = []time.Duration{90 * time.Minute}
+	icsData, _ = service.GenerateEventICS(planning, event, "fr")
+	ics = string(icsData)
+	if !contains(ics, "TRIGGER:-PT1H30M") || contains(ics, "TRIGGER:-P1D") {
+		t.Errorf("Event reminders not applied:\n%s", ics)
+	}
+
+	// Liste vide : aucun rappel
+	event.Reminders = []time.Duration{}
+	icsData, _ = service.GenerateEventICS(planning, event, "fr")
+	if contains(string(icsData), "BEGIN:VALARM") {
+		t.Error("Event with empty reminders should have no VALARM")
+	}
+
+	// Le planning par défaut règle ses propres rappels par événement
+	icsData, err := service.GenerateICS(planning, "fr")
+	if err != nil {
+		t.Fatalf("GenerateICS() error = %v", err)
+	}
+	ics = string(icsData)
+	for _, want := range []string{
+		"TRIGGER:-PT1H30M\r\nDESCRIPTION:Rappel : Cérémonie civile",
+		"TRIGGER:-PT30M\r\nDESCRIPTION:Rappel : Cérémonie laïque",
+		"TRIGGER:-PT2H\r\nDESCRIPTION:Rappel : Vin d'honneur",
+	} {
+		if !contains(ics, want) {
+			t.Errorf("Default planning ICS missing %q", want)
+		}
+	}
+	if contains(ics, "TRIGGER:-PT2H\r\nDESCRIPTION:Rappel : Cérémonie laïque") {
+		t.Error("Cérémonie laïque should not use the default reminders")
+	}
+}
+
+func TestVenueService_Directions(t *testing.T) {
+	service := NewVenueService()
+	venue := &domain.Venue{Name: "La Bergerie", Address: "Rue de la Bascule, 77190 Villiers-en-Bière", Latitude: 48.48103, Longitude: 2.5959}
+
+	osm := service.OpenStreetMapDirectionsURL(venue)
+	if osm != "https://www.openstreetmap.org/directions?route=%3B48.481030%2C2.595900" {
+		t.Errorf("OpenStreetMapDirectionsURL() = %s", osm)
+	}
+
+	google := service.GoogleMapsDirectionsURL(venue)
+	if google != "https://www.google.com/maps/dir/?api=1&destination=48.481030%2C2.595900" {
+		t.Errorf("GoogleMapsDirectionsURL() = %s", google)
+	}
+
+	// Sans coordonnées, l'adresse sert de destination
+	venue.Latitude, venue.Longitude = 0, 0
+	if google := service.GoogleMapsDirectionsURL(venue); !contains(google, "destination=Rue%20de%20la%20Bascule") {
+		t.Errorf("GoogleMapsDirectionsURL() = %s, want address destination", google)
+	}
+	if service.GoogleMapsDirectionsURL(nil) != "" {
+		t.Error("GoogleMapsDirectionsURL(nil) should be empty")
+	}
+}
+
+func TestVenueService_StaticMap(t *testing.T) {
+	service := NewVenueService()
+
+	if service.StaticMap(nil) != nil {
+		t.Error("StaticMap() without venues should be nil")
+	}
+
+	venues := domain.GetDefaultVenues()
+	venues = append(venues, &domain.Venue{Name: "Sans coordonnées"})
+
+	staticMap := service.StaticMap(venues)
+	if staticMap == nil {
+		t.Fatal("StaticMap() returned nil")
+	}
+	if len(staticMap.Markers) != 3 {
+		t.Fatalf("StaticMap() markers = %d, want 3", len(staticMap.Markers))
+	}
+
+	for i, marker := range staticMap.Markers {
+		if marker.Number != i+1 {
+			t.Errorf("marker %d Number = %d", i, marker.Number)
+		}
+		if marker.X < 0 || marker.X > float64(staticMap.Width) || marker.Y < 0 || marker.Y > float64(staticMap.Height) {
+			t.Errorf("marker %q out of bounds: (%.1f, %.1f)", marker.Venue.Name, marker.X, marker.Y)
+		}
+		// Les repères voisins doivent rester lisibles
+		for _, other := range staticMap.Markers[i+1:] {
+			if dx, dy := marker.X-other.X, marker.Y-other.Y; dx*dx+dy*dy < 30*30 {
+				t.Errorf("markers %q and %q overlap", marker.Venue.Name, other.Venue.Name)
+			}
+		}
+	}
+
+	// La Bergerie est au nord-est de Cély
+	mairie, bergerie := staticMap.Markers[0], staticMap.Markers[2]
+	if bergerie.X <= mairie.X || bergerie.Y >= mairie.Y {
+		t.Error("StaticMap() projection does not preserve orientation")
+	}
+}
+
 func TestPlanningService(t *testing.T) {
 	service := NewPlanningService()
 	planning := service.GetPlanning()
@@ -153,16 +807,48 @@ func TestPlanningService(t *testing.T) {
 	}
 }
 
+// Mock source d'infos pratiques pour les tests
+type mockPracticalInfoSource map[string]*domain.PracticalInfo
+
+func (m mockPracticalInfoSource) LoadPracticalInfo(lang string) (*domain.PracticalInfo, error) {
+	if info, ok := m[lang]; ok {
+		return info, nil
+	}
+	return nil, os.ErrNotExist
+}
+
 func TestInfoService(t *testing.T) {
-	service := NewInfoService()
-	info := service.GetPracticalInfo()
+	service := NewInfoService(mockPracticalInfoSource{
+		"fr": {Sections: []domain.Info{
+			{Title: "Le lieu"},
+			{Title: "Hébergement", Audience: []string{"famille"}},
+		}},
+	})
 
-	if info == nil {
-		t.Fatal("GetPracticalInfo() returned nil")
+	info, err := service.GetPracticalInfo(i18n.FR)
+	if err != nil {
+		t.Fatalf("GetPracticalInfo() error = %v", err)
+	}
+	if len(info.Sections) != 2 || info.Sections[0].Title != "Le lieu" {
+		t.Errorf("GetPracticalInfo() sections = %v", info.Sections)
+	}
+	if len(info.Venues) == 0 {
+		t.Error("GetPracticalInfo() should include the venues")
+	}
+
+	// Sans contenu allemand, la version française est utilisée
+	info, err = service.GetPracticalInfoFor(nil, i18n.DE)
+	if err != nil {
+		t.Fatalf("GetPracticalInfoFor() error = %v", err)
+	}
+	if len(info.Sections) != 1 {
+		t.Errorf("GetPracticalInfoFor(nil) returned %d sections, want 1", len(info.Sections))
 	}
 
-	if info.Venue.Title == "" {
-		t.Error("Venue title is empty")
+	// Sans aucun contenu, l'erreur est remontée
+	empty := NewInfoService(mockPracticalInfoSource{})
+	if _, err := empty.GetPracticalInfo(i18n.FR); !errors.Is(err, ErrContentUnavailable) {
+		t.Errorf("GetPracticalInfo() error = %v, want %v", err, ErrContentUnavailable)
 	}
 }
 
@@ -178,3 +864,817 @@ func containsInMiddle(s, substr string) bool {
 	}
 	return false
 }
+
+// Mock livre d'or pour les tests
+func newMockGuestbookStorage() *mockCollection[domain.GuestbookEntry] {
+	return newMockCollection(func(item *domain.GuestbookEntry) string { return item.ID }, ErrGuestbookEntryNotFound, nil)
+}
+
+func TestGuestbookService(t *testing.T) {
+	storage := newMockGuestbookStorage()
+	service := NewGuestbookService(storage)
+
+	dupont := &domain.Invitation{ID: "inv-1", Name: "Famille Dupont"}
+	first, err := service.PostEntry(dupont, "Jean", "Tous nos vœux !", "127.0.0.1")
+	if err != nil {
+		t.Fatalf("PostEntry() error = %v", err)
+	}
+	if first.InvitationID != "inv-1" {
+		t.Errorf("InvitationID = %q, want inv-1", first.InvitationID)
+	}
+	second, _ := service.PostEntry(nil, "Marie", "Félicitations", "127.0.0.1")
+	second.CreatedAt = first.CreatedAt.Add(time.Second)
+
+	if _, err := service.PostEntry(nil, "Spam", "Visitez www.exemple.com", "127.0.0.1"); err != domain.ErrLinkNotAllowed {
+		t.Errorf("PostEntry() error = %v, want %v", err, domain.ErrLinkNotAllowed)
+	}
+
+	// Rien n'est publié avant modération
+	if approved, _ := service.Approved(); len(approved) != 0 {
+		t.Errorf("Approved() = %d entries before moderation, want 0", len(approved))
+	}
+	pending, _ := service.Pending()
+	if len(pending) != 2 || pending[0] != first {
+		t.Fatalf("Pending() = %+v, want oldest first", pending)
+	}
+
+	if err := service.Approve(first.ID); err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if err := service.Approve(second.ID); err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	second.ApprovedAt = first.ApprovedAt.Add(time.Second)
+	if err := service.Approve("inconnu"); err != ErrGuestbookEntryNotFound {
+		t.Errorf("Approve() unknown error = %v, want %v", err, ErrGuestbookEntryNotFound)
+	}
+
+	approved, _ := service.Approved()
+	if len(approved) != 2 || approved[0] != second {
+		t.Errorf("Approved() = %+v, want newest first", approved)
+	}
+	if pending, _ := service.Pending(); len(pending) != 0 {
+		t.Errorf("Pending() = %d entries after moderation, want 0", len(pending))
+	}
+
+	if err := service.DeleteEntry(first.ID); err != nil {
+		t.Fatalf("DeleteEntry() error = %v", err)
+	}
+	if approved, _ := service.Approved(); len(approved) != 1 {
+		t.Errorf("Approved() after delete = %d entries, want 1", len(approved))
+	}
+}
+
+// Mock liste de mariage pour les tests
+func newMockRegistryStorage() *mockCollection[domain.RegistryItem] {
+	return newMockCollection(func(item *domain.RegistryItem) string { return item.ID }, ErrRegistryItemNotFound, nil)
+}
+
+func TestRegistryService(t *testing.T) {
+	service := NewRegistryService(newMockRegistryStorage())
+
+	dupont := &domain.Invitation{ID: "inv-1", Name: "Famille Dupont"}
+	martin := &domain.Invitation{ID: "inv-2", Name: "Famille Martin"}
+
+	gift, err := service.AddItem("Service à raclette", "Pour 8 personnes", "", 80, false)
+	if err != nil {
+		t.Fatalf("AddItem() error = %v", err)
+	}
+	fund, _ := service.AddItem("Voyage de noces", "", "", 3000, true)
+	fund.CreatedAt = gift.CreatedAt.Add(time.Second)
+
+	if _, err := service.AddItem("", "", "", 80, false); err != domain.ErrInvalidGift {
+		t.Errorf("AddItem() error = %v, want %v", err, domain.ErrInvalidGift)
+	}
+
+	reservation, err := service.Reserve(dupont, gift.ID, "Jean Dupont", true, 0)
+	if err != nil {
+		t.Fatalf("Reserve() error = %v", err)
+	}
+	if reservation.Amount != 80 || reservation.ID == "" {
+		t.Errorf("Reserve() = %+v, want the stored reservation at the gift price", reservation)
+	}
+	if _, err := service.Reserve(martin, gift.ID, "Marie Martin", false, 0); err != domain.ErrGiftAlreadyReserved {
+		t.Errorf("Reserve() twice error = %v, want %v", err, domain.ErrGiftAlreadyReserved)
+	}
+	if _, err := service.Reserve(martin, "inconnu", "Marie Martin", false, 0); err != ErrRegistryItemNotFound {
+		t.Errorf("Reserve() unknown error = %v, want %v", err, ErrRegistryItemNotFound)
+	}
+	service.Reserve(martin, fund.ID, "Marie Martin", false, 150)
+	service.Reserve(nil, fund.ID, "Paul", false, 50)
+
+	// Un foyer n'annule que ses propres réservations
+	if err := service.ReleaseOwn(martin, gift.ID, reservation.ID); err != domain.ErrReservationNotFound {
+		t.Errorf("ReleaseOwn() other household error = %v, want %v", err, domain.ErrReservationNotFound)
+	}
+
+	items, err := service.ListItems()
+	if err != nil || len(items) != 2 || items[0] != gift {
+		t.Fatalf("ListItems() = %+v, %v", items, err)
+	}
+	if !items[0].IsReserved() || items[1].Collected() != 200 {
+		t.Errorf("ListItems() reserved = %v, collected = %d", items[0].IsReserved(), items[1].Collected())
+	}
+
+	// L'export liste chaque réservation, noms des anonymes compris
+	f, err := newTestExportService().ExportRegistryToExcel(items)
+	if err != nil {
+		t.Fatalf("ExportRegistryToExcel() error = %v", err)
+	}
+	if name, _ := f.GetCellValue("Cadeaux", "D2"); name != "Jean Dupont" {
+		t.Errorf("Export D2 = %q, want Jean Dupont", name)
+	}
+	if rows, _ := f.GetRows("Cadeaux"); len(rows) != 4 {
+		t.Errorf("Export has %d rows, want 4", len(rows))
+	}
+
+	if err := service.ReleaseOwn(dupont, gift.ID, reservation.ID); err != nil {
+		t.Fatalf("ReleaseOwn() error = %v", err)
+	}
+	if gift.IsReserved() {
+		t.Error("Gift should be available after release")
+	}
+}
+
+// Mock photos pour les tests
+func newMockPhotoStorage() *mockCollection[domain.Photo] {
+	return newMockCollection(func(item *domain.Photo) string { return item.ID }, ErrPhotoNotFound, nil)
+}
+
+// Mock fichiers image pour les tests
+type mockPhotoFiles struct {
+	images map[string][]byte
+}
+
+func (m *mockPhotoFiles) SaveImages(id string, photo, thumbnail []byte) error {
+	m.images[id] = photo
+	m.images[id+"_thumb"] = thumbnail
+	return nil
+}
+
+func (m *mockPhotoFiles) ReadImage(id string, thumbnail bool) ([]byte, error) {
+	if thumbnail {
+		id += "_thumb"
+	}
+	data, ok := m.images[id]
+	if !ok {
+		return nil, ErrPhotoNotFound
+	}
+	return data, nil
+}
+
+func (m *mockPhotoFiles) DeleteImages(id string) error {
+	delete(m.images, id)
+	delete(m.images, id+"_thumb")
+	return nil
+}
+
+func TestPhotoService(t *testing.T) {
+	storage := newMockPhotoStorage()
+	files := &mockPhotoFiles{images: map[string][]byte{}}
+	service := NewPhotoService(storage, files)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 3000, 1500))); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	if _, err := service.Upload(nil, "Jean", "", "image/png", data); err != ErrInvitationRequired {
+		t.Errorf("Upload() without invitation error = %v, want %v", err, ErrInvitationRequired)
+	}
+	dupont := &domain.Invitation{ID: "inv-1", Name: "Famille Dupont"}
+	if _, err := service.Upload(dupont, "Jean", "", "image/gif", data); err != domain.ErrUnsupportedPhotoType {
+		t.Errorf("Upload() gif error = %v, want %v", err, domain.ErrUnsupportedPhotoType)
+	}
+	if _, err := service.Upload(dupont, "Jean", "", "image/jpeg", []byte("pas une image")); err != domain.ErrInvalidPhoto {
+		t.Errorf("Upload() corrupted error = %v, want %v", err, domain.ErrInvalidPhoto)
+	}
+
+	photo, err := service.Upload(dupont, "Jean", "Le gâteau", "image/png", data)
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if photo.InvitationID != "inv-1" || photo.Width != photoMaxSide || photo.Height != photoMaxSide/2 {
+		t.Errorf("Upload() = %+v, want a %dx%d photo of inv-1", photo, photoMaxSide, photoMaxSide/2)
+	}
+
+	// Une photo en attente n'est visible que de l'administration
+	if _, err := service.PublicImage(photo.ID, true); err != ErrPhotoNotFound {
+		t.Errorf("PublicImage() pending error = %v, want %v", err, ErrPhotoNotFound)
+	}
+	if _, err := service.Image(photo.ID, true); err != nil {
+		t.Errorf("Image() pending error = %v", err)
+	}
+
+	if err := service.Approve(photo.ID); err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if approved, _ := service.Approved(); len(approved) != 1 {
+		t.Errorf("Approved() = %d photos, want 1", len(approved))
+	}
+	if _, err := service.PublicImage(photo.ID, false); err != nil {
+		t.Errorf("PublicImage() approved error = %v", err)
+	}
+
+	if err := service.DeletePhoto(photo.ID); err != nil {
+		t.Fatalf("DeletePhoto() error = %v", err)
+	}
+	if len(files.images) != 0 || len(storage.items) != 0 {
+		t.Errorf("DeletePhoto() left %d images and %d photos", len(files.images), len(storage.items))
+	}
+}
+
+// Mock plan de table pour les tests
+func newMockSeatingStorage() *mockCollection[domain.Table] {
+	return newMockCollection(func(item *domain.Table) string { return item.ID }, ErrTableNotFound, nil)
+}
+
+// newTestExportService crée un service d'export sans réponses ni plan de table
+func newTestExportService() *ExportService {
+	rsvpService := NewRSVPService(&mockStorage{}, NewPlanningService())
+	return NewExportService(rsvpService, NewSeatingService(newMockSeatingStorage(), rsvpService))
+}
+
+func TestRSVPServiceAttendingRSVPs(t *testing.T) {
+	now := time.Now()
+	storage := &mockStorage{rsvps: []*domain.RSVP{
+		{ID: "old", InvitationID: "inv-1", LastName: "Dupont", WillAttend: true, AdultsCount: 2, SubmittedAt: now.Add(-time.Hour)},
+		{ID: "new", InvitationID: "inv-1", LastName: "Dupont", WillAttend: true, AdultsCount: 3, SubmittedAt: now},
+		{ID: "declined", InvitationID: "inv-2", LastName: "Bernard", WillAttend: false, SubmittedAt: now},
+		{ID: "solo", LastName: "Martin", WillAttend: true, AdultsCount: 1, SubmittedAt: now},
+		{ID: "alpha", LastName: "arnaud", WillAttend: true, AdultsCount: 1, SubmittedAt: now},
+	}}
+
+	attending, err := NewRSVPService(storage, NewPlanningService()).AttendingRSVPs()
+	if err != nil {
+		t.Fatalf("AttendingRSVPs() error = %v", err)
+	}
+
+	var ids []string
+	for _, rsvp := range attending {
+		ids = append(ids, rsvp.ID)
+	}
+	if strings.Join(ids, ",") != "alpha,new,solo" {
+		t.Errorf("AttendingRSVPs() = %v, want latest answers of attending households sorted by name", ids)
+	}
+}
+
+func TestSeatingService(t *testing.T) {
+	rsvpStorage := &mockStorage{rsvps: []*domain.RSVP{
+		{ID: "dupont", FirstName: "Jean", LastName: "Dupont", WillAttend: true, AdultsCount: 2, ChildrenCount: 2, SubmittedAt: time.Now()},
+		{ID: "martin", FirstName: "Marie", LastName: "Martin", WillAttend: true, AdultsCount: 1, SubmittedAt: time.Now()},
+		{ID: "absent", FirstName: "Paul", LastName: "Absent", WillAttend: false, SubmittedAt: time.Now()},
+	}}
+	rsvpService := NewRSVPService(rsvpStorage, NewPlanningService())
+	storage := newMockSeatingStorage()
+	service := NewSeatingService(storage, rsvpService)
+
+	honneur, err := service.AddTable("Honneur", 2)
+	if err != nil {
+		t.Fatalf("AddTable() error = %v", err)
+	}
+	enfants, _ := service.AddTable("Enfants", 6)
+	enfants.CreatedAt = honneur.CreatedAt.Add(time.Second)
+
+	if err := service.Assign(honneur.ID, "dupont", 2, 0); err != nil {
+		t.Fatalf("Assign() error = %v", err)
+	}
+	if err := service.Assign(enfants.ID, "dupont", 1, 2); err != domain.ErrInvalidSeating {
+		t.Errorf("Assign() beyond the household error = %v, want %v", err, domain.ErrInvalidSeating)
+	}
+	if err := service.Assign(enfants.ID, "absent", 1, 0); err != domain.ErrInvalidSeating {
+		t.Errorf("Assign() absent household error = %v, want %v", err, domain.ErrInvalidSeating)
+	}
+	if err := service.Assign("inconnue", "martin", 1, 0); err != ErrTableNotFound {
+		t.Errorf("Assign() unknown table error = %v, want %v", err, ErrTableNotFound)
+	}
+	service.Assign(enfants.ID, "dupont", 0, 2)
+	service.Assign(honneur.ID, "martin", 1, 0)
+
+	plan, err := service.Plan()
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if plan.Attending != 5 || plan.Seated != 5 || plan.Capacity != 8 || len(plan.Unseated) != 0 {
+		t.Errorf("Plan() = %d/%d seated, capacity %d, %d unseated", plan.Seated, plan.Attending, plan.Capacity, len(plan.Unseated))
+	}
+	if len(plan.Tables) != 2 || plan.Tables[0].Name != "Honneur" || len(plan.Tables[0].Parties) != 2 {
+		t.Fatalf("Plan().Tables = %+v", plan.Tables)
+	}
+	kinds := map[domain.SeatingWarningKind]string{}
+	for _, warning := range plan.Warnings {
+		kinds[warning.Kind] = warning.Guest
+	}
+	if len(plan.Warnings) != 2 || kinds[domain.WarningOverCapacity] != "" || kinds[domain.WarningChildrenAlone] != "Jean Dupont" {
+		t.Errorf("Plan().Warnings = %+v, want over capacity and children alone", plan.Warnings)
+	}
+
+	// Supprimer une table remet ses invités à placer
+	if err := service.DeleteTable(honneur.ID); err != nil {
+		t.Fatalf("DeleteTable() error = %v", err)
+	}
+	plan, _ = service.Plan()
+	if len(plan.Unseated) != 2 || plan.Unseated[0].Adults != 2 || plan.Unseated[0].Children != 0 {
+		t.Errorf("Plan().Unseated = %+v, want the adults of both households", plan.Unseated)
+	}
+
+	// L'export des RSVP contient la feuille du plan de table
+	f, err := NewExportService(rsvpService, service).ExportRSVPsToExcel()
+	if err != nil {
+		t.Fatalf("ExportRSVPsToExcel() error = %v", err)
+	}
+	if table, _ := f.GetCellValue("Plan de table", "A2"); table != "Enfants" {
+		t.Errorf("Plan de table!A2 = %q, want Enfants", table)
+	}
+	if unseated, _ := f.GetCellValue("Plan de table", "A3"); unseated != "À placer" {
+		t.Errorf("Plan de table!A3 = %q, want À placer", unseated)
+	}
+}
+
+func TestPrintService(t *testing.T) {
+	rsvpStorage := &mockStorage{rsvps: []*domain.RSVP{
+		{ID: "muller", FirstName: "Anna", LastName: "Müller", WillAttend: true, AdultsCount: 2, ChildrenCount: 1, SubmittedAt: time.Now()},
+		{ID: "bernard", FirstName: "Luc", LastName: "Bernard", WillAttend: true, AdultsCount: 1, SubmittedAt: time.Now(), Lang: "fr"},
+	}}
+	rsvpService := NewRSVPService(rsvpStorage, NewPlanningService())
+	seatingService := NewSeatingService(newMockSeatingStorage(), rsvpService)
+	service := NewPrintService(seatingService)
+
+	table, _ := seatingService.AddTable("Les Tilleuls", 8)
+	seatingService.Assign(table.ID, "muller", 2, 1)
+	seatingService.Assign(table.ID, "bernard", 1, 0)
+
+	escort, err := service.EscortCards("de")
+	if err != nil {
+		t.Fatalf("EscortCards() error = %v", err)
+	}
+	if len(escort) != 2 || escort[0].Name != "Luc Bernard" || escort[1].Detail != "3 Personen" || escort[1].Table != "Les Tilleuls" {
+		t.Errorf("EscortCards() = %+v, want one card per household sorted by name", escort)
+	}
+	// Les cartes suivent la langue de la réponse, à défaut celle du document
+	if len(escort) == 2 && (escort[0].Lang != "fr" || escort[1].Lang != "de") {
+		t.Errorf("EscortCards() langs = %s, %s, want fr for Bernard and de for Müller", escort[0].Lang, escort[1].Lang)
+	}
+
+	place, err := service.PlaceCards("fr")
+	if err != nil {
+		t.Fatalf("PlaceCards() error = %v", err)
+	}
+	var names []string
+	for _, card := range place {
+		names = append(names, card.Name)
+	}
+	if strings.Join(names, ",") != "Luc Bernard,Anna Müller,Invité(e) de Anna Müller,Enfant de Anna Müller" {
+		t.Errorf("PlaceCards() names = %v, want one card per seated person", names)
+	}
+
+	for _, document := range []PrintDocument{PrintEscortCards, PrintPlaceCards, PrintTablePlan} {
+		var buf bytes.Buffer
+		if err := service.WritePDF(&buf, document, "fr"); err != nil {
+			t.Fatalf("WritePDF(%s) error = %v", document, err)
+		}
+		if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+			t.Errorf("WritePDF(%s) did not produce a PDF", document)
+		}
+	}
+	if err := service.WritePDF(&bytes.Buffer{}, "badge", "fr"); err != ErrUnknownDocument {
+		t.Errorf("WritePDF(badge) error = %v, want %v", err, ErrUnknownDocument)
+	}
+}
+
+func TestTablePlanPDF_Unicode(t *testing.T) {
+	plan := &SeatingPlan{Tables: []TableView{{
+		Table:   &domain.Table{Name: "Les Tilleuls"},
+		Parties: []SeatedParty{{RSVP: &domain.RSVP{FirstName: "Łukasz", LastName: "Παπαδόπουλος"}, Adults: 1}},
+	}}}
+
+	pdf := tablePlanPDF(plan, i18n.NewTranslations(i18n.FR))
+	pdf.SetCompression(false)
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		t.Fatalf("Output() error = %v", err)
+	}
+
+	// Les noms hors Windows-1252 sont écrits tels quels avec la police UTF-8
+	if !bytes.Contains(buf.Bytes(), utf16BE("Łukasz Παπαδόπουλος")) {
+		t.Error("tablePlanPDF() did not write the Polish and Greek name with the UTF-8 font")
+	}
+}
+
+func TestTablePlanPDF_PageBreak(t *testing.T) {
+	parties := make([]SeatedParty, 60)
+	for i := range parties {
+		parties[i] = SeatedParty{RSVP: &domain.RSVP{FirstName: "Invité", LastName: fmt.Sprint(i)}, Adults: 1}
+	}
+	plan := &SeatingPlan{Tables: []TableView{
+		{Table: &domain.Table{Name: "Les Tilleuls"}, Parties: parties[:2]},
+		{Table: &domain.Table{Name: "Banquet"}, Parties: parties},
+		{Table: &domain.Table{Name: "Vide"}},
+	}}
+
+	// Une table plus haute qu'une page A2 (41 lignes) se poursuit sur la suivante
+	pdf := tablePlanPDF(plan, i18n.NewTranslations(i18n.FR))
+	if err := pdf.Error(); err != nil {
+		t.Fatalf("tablePlanPDF() error = %v", err)
+	}
+	if pdf.PageCount() != 2 {
+		t.Errorf("tablePlanPDF() PageCount() = %d, want 2", pdf.PageCount())
+	}
+
+	// Plusieurs rangées hautes ne se chevauchent pas : chacune sur sa page
+	tall := &SeatingPlan{}
+	for i := 0; i < 12; i++ {
+		tall.Tables = append(tall.Tables, TableView{Table: &domain.Table{Name: fmt.Sprint(i)}, Parties: parties[:30]})
+	}
+	if pages := tablePlanPDF(tall, i18n.NewTranslations(i18n.FR)).PageCount(); pages != 3 {
+		t.Errorf("tablePlanPDF() PageCount() = %d, want one page per row", pages)
+	}
+}
+
+// utf16BE encode un texte comme les chaînes des polices UTF-8 du PDF
+func utf16BE(text string) []byte {
+	var buf bytes.Buffer
+	for _, r := range utf16.Encode([]rune(text)) {
+		buf.WriteByte(byte(r >> 8))
+		buf.WriteByte(byte(r))
+	}
+	return buf.Bytes()
+}
+
+// Mock storage pour les invitations
+type mockInvitationStorage struct {
+	*mockCollection[domain.Invitation]
+}
+
+func newMockInvitationStorage(invitations []*domain.Invitation) *mockInvitationStorage {
+	return &mockInvitationStorage{newMockCollection(func(item *domain.Invitation) string { return item.ID }, ErrInvitationNotFound, invitations)}
+}
+
+func (m *mockInvitationStorage) FindByCode(code string) (*domain.Invitation, error) {
+	return m.find(func(invitation *domain.Invitation) bool { return invitation.Code == code })
+}
+
+// Mock storage pour le suivi des remerciements
+func newMockThankYouStorage() *mockCollection[domain.ThankYou] {
+	return newMockCollection(func(item *domain.ThankYou) string { return item.ID }, errors.New("remerciement non trouvé"), nil)
+}
+
+func TestThankYouService(t *testing.T) {
+	invitations := newMockInvitationStorage([]*domain.Invitation{
+		{ID: "inv-weber", Code: "weber", Name: "Famille Weber"},
+		{ID: "inv-adam", Code: "adam", Name: "Famille Adam"},
+	})
+	rsvpService := NewRSVPService(&mockStorage{rsvps: []*domain.RSVP{
+		{ID: "weber", InvitationID: "inv-weber", FirstName: "Eva", LastName: "Weber", WillAttend: true, AdultsCount: 2, SubmittedAt: time.Now(), Lang: "de"},
+		{ID: "solo", FirstName: "Marc", LastName: "Zimmer", WillAttend: false, SubmittedAt: time.Now()},
+	}}, NewPlanningService())
+	registryService := NewRegistryService(newMockRegistryStorage())
+	fund, _ := registryService.AddItem("Voyage de noces", "", "", 3000, true)
+	registryService.Reserve(&domain.Invitation{ID: "inv-weber"}, fund.ID, "Eva", false, 150)
+
+	service := NewThankYouService(newMockThankYouStorage(), rsvpService, NewInvitationService(invitations), registryService)
+
+	households, err := service.Households(ThanksAll)
+	if err != nil {
+		t.Fatalf("Households() error = %v", err)
+	}
+	var names []string
+	for _, household := range households {
+		names = append(names, household.Name)
+	}
+	if strings.Join(names, ",") != "Famille Adam,Famille Weber,Marc Zimmer" {
+		t.Fatalf("Households() = %v, want invitations and answers without invitation sorted by name", names)
+	}
+	if weber := households[1]; weber.RSVP == nil || weber.RSVP.ID != "weber" || strings.Join(weber.RegistryGifts, ",") != "Voyage de noces (150 €)" {
+		t.Errorf("Households()[Weber] = %+v, want its answer and registry contribution", weber)
+	}
+
+	address := domain.PostalAddress{Street: "3 Hauptstraße", PostalCode: "77694", City: "Kehl", Country: "Allemagne"}
+	if err := service.Save("inv-weber", "", "Cafetière", "Merci pour la cagnotte", address); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := service.Save("", "", "Cafetière", "", address); err != domain.ErrInvalidThankYou {
+		t.Errorf("Save() without household error = %v, want %v", err, domain.ErrInvalidThankYou)
+	}
+	if err := service.MarkSent("inv-weber", "", true); err != nil {
+		t.Fatalf("MarkSent() error = %v", err)
+	}
+
+	pending, _ := service.Households(ThanksPending)
+	sent, _ := service.Households(ThanksSent)
+	if len(pending) != 2 || len(sent) != 1 || sent[0].Record.Gift != "Cafetière" {
+		t.Errorf("Households() = %d pending, %d sent (%+v), want 2 and Weber", len(pending), len(sent), sent)
+	}
+
+	// L'export CSV contient les adresses pour le publipostage
+	var buf bytes.Buffer
+	if err := newTestExportService().ExportThankYousToCSV(&buf, sent); err != nil {
+		t.Fatalf("ExportThankYousToCSV() error = %v", err)
+	}
+	for _, want := range []string{"Famille Weber,Eva,Weber,3 Hauptstraße,77694,Kehl,Allemagne,DE,Cafetière,Voyage de noces (150 €)", time.Now().Format("02/01/2006")} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("ExportThankYousToCSV() = %q, want %q", buf.String(), want)
+		}
+	}
+
+	// Les noms saisis par les invités ne sont pas exécutés par Excel
+	buf.Reset()
+	newTestExportService().ExportThankYousToCSV(&buf, []ThankYouHousehold{{
+		Name:   "=cmd|' /C calc'!A0",
+		RSVP:   &domain.RSVP{FirstName: "+Eva", LastName: "@Weber"},
+		Record: &domain.ThankYou{},
+	}})
+	if !strings.Contains(buf.String(), "'=cmd|' /C calc'!A0,'+Eva,'@Weber,") {
+		t.Errorf("ExportThankYousToCSV() = %q, want formulas neutralised", buf.String())
+	}
+}
+
+// Mock storage pour la file d'envoi des emails
+func newMockEmailQueueStorage() *mockCollection[domain.QueuedEmail] {
+	return newMockCollection(func(item *domain.QueuedEmail) string { return item.ID }, errors.New("email non trouvé"), nil)
+}
+
+// Mock mailer : enregistre les emails envoyés, ou échoue
+type mockMailer struct {
+	sent []domain.Email
+	err  error
+}
+
+func (m *mockMailer) Send(email domain.Email) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, email)
+	return nil
+}
+
+func TestNotificationService(t *testing.T) {
+	mailer := &mockMailer{err: errors.New("serveur injoignable")}
+	queue := newMockEmailQueueStorage()
+	service, err := NewNotificationService(mailer, queue, NewCalendarService(nil), "../../web/templates/emails", []string{"marie@example.com"}, false, NotifyEachRSVP, 8*time.Hour, 2, "https://mariage.example.com")
+	if err != nil {
+		t.Fatalf("NewNotificationService() error = %v", err)
+	}
+
+	rsvp, _ := domain.NewRSVP("Anna", "Müller", true, 2, 1, "Sans gluten", "À bientôt !")
+	rsvp.Lang = "de"
+	if err := service.RSVPSubmitted(rsvp, &domain.Invitation{Name: "Famille Müller"}); err != nil {
+		t.Fatalf("RSVPSubmitted() error = %v", err)
+	}
+
+	// Un échec d'envoi garde l'email dans la file et le reprogramme
+	now := time.Now()
+	if err := service.ProcessQueue(now); err == nil {
+		t.Fatal("ProcessQueue() error = nil, want the mailer error")
+	}
+	if len(queue.items) != 1 || queue.items[0].Attempts != 1 || queue.items[0].IsDue(now) {
+		t.Fatalf("queue = %+v, want the email rescheduled", queue.items)
+	}
+
+	// Nouvel essai réussi à la date prévue
+	mailer.err = nil
+	if err := service.ProcessQueue(queue.items[0].NextAttempt); err != nil {
+		t.Fatalf("ProcessQueue() error = %v", err)
+	}
+	if len(mailer.sent) != 1 || len(queue.items) != 0 {
+		t.Fatalf("sent %d emails, %d queued, want 1 and 0", len(mailer.sent), len(queue.items))
+	}
+	email := mailer.sent[0]
+	if email.Subject != "✓ Réponse de Anna Müller (Famille Müller)" {
+		t.Errorf("Subject = %q", email.Subject)
+	}
+	for _, want := range []string{"Adultes : 2", "Allergies/Régimes : Sans gluten", "À bientôt !", "Langue : DE", "https://mariage.example.com/admin"} {
+		if !strings.Contains(email.Body, want) {
+			t.Errorf("Body = %q, want %q", email.Body, want)
+		}
+	}
+
+	// Abandon après le nombre maximal de tentatives
+	mailer.err = errors.New("serveur injoignable")
+	service.Enqueue(domain.Email{To: []string{"marie@example.com"}, Subject: "Test", Body: "Test"})
+	service.ProcessQueue(time.Now())
+	service.ProcessQueue(queue.items[0].NextAttempt)
+	if len(queue.items) != 0 {
+		t.Errorf("queue = %+v, want the email abandoned after 2 attempts", queue.items)
+	}
+}
+
+func TestNotificationService_Digest(t *testing.T) {
+	mailer := &mockMailer{}
+	queue := newMockEmailQueueStorage()
+	service, err := NewNotificationService(mailer, queue, NewCalendarService(nil), "../../web/templates/emails", []string{"marie@example.com", "paul@example.com"}, false, NotifyDigest, 8*time.Hour, 10, "https://mariage.example.com")
+	if err != nil {
+		t.Fatalf("NewNotificationService() error = %v", err)
+	}
+
+	for _, name := range []string{"Anna", "Luc"} {
+		rsvp, _ := domain.NewRSVP(name, "Martin", false, 0, 0, "", "")
+		if err := service.RSVPSubmitted(rsvp, nil); err != nil {
+			t.Fatalf("RSVPSubmitted() error = %v", err)
+		}
+	}
+
+	// Rien ne part avant l'heure du récapitulatif
+	service.ProcessQueue(time.Now())
+	if len(mailer.sent) != 0 {
+		t.Fatalf("sent %d emails before the digest time, want 0", len(mailer.sent))
+	}
+
+	digestAt := queue.items[0].NextAttempt
+	if digestAt.Hour() != 8 || digestAt.Minute() != 0 || !digestAt.After(time.Now()) {
+		t.Errorf("NextAttempt = %v, want the next 08:00", digestAt)
+	}
+	if err := service.ProcessQueue(digestAt); err != nil {
+		t.Fatalf("ProcessQueue() error = %v", err)
+	}
+	if len(mailer.sent) != 1 || len(queue.items) != 0 {
+		t.Fatalf("sent %d emails, %d queued, want a single digest", len(mailer.sent), len(queue.items))
+	}
+	digest := mailer.sent[0]
+	if !strings.Contains(digest.Subject, "2 nouvelle(s) réponse(s)") || len(digest.To) != 2 {
+		t.Errorf("digest = %+v", digest)
+	}
+	if first, second := strings.Index(digest.Body, "Anna Martin"), strings.Index(digest.Body, "Luc Martin"); first < 0 || second < first {
+		t.Errorf("Body = %q, want both answers in order", digest.Body)
+	}
+	if strings.Count(digest.Body, "/admin") != 1 {
+		t.Errorf("Body = %q, want the admin link once", digest.Body)
+	}
+}
+
+func TestNotificationService_ConfirmRSVP(t *testing.T) {
+	mailer := &mockMailer{}
+	queue := newMockEmailQueueStorage()
+	service, err := NewNotificationService(mailer, queue, NewCalendarService(nil), "../../web/templates/emails", nil, true, NotifyEachRSVP, 8*time.Hour, 10, "https://mariage.example.com")
+	if err != nil {
+		t.Fatalf("NewNotificationService() error = %v", err)
+	}
+
+	// Sans destinataires, les mariés ne sont pas prévenus
+	rsvp, _ := domain.NewRSVP("Anna", "Müller", true, 2, 0, "", "Bis bald!")
+	rsvp.Lang = "de"
+	rsvp.AccommodationNights = []string{"2026-07-11"}
+	if err := service.RSVPSubmitted(rsvp, nil); err != nil || len(queue.items) != 0 {
+		t.Fatalf("RSVPSubmitted() = %v with %d queued, want nothing without recipients", err, len(queue.items))
+	}
+
+	// Sans adresse, pas de confirmation
+	if err := service.ConfirmRSVP(rsvp, domain.GetDefaultPlanning(), "https://mariage.example.com/rsvp?edit=abc"); err != nil || len(queue.items) != 0 {
+		t.Fatalf("ConfirmRSVP() = %v with %d queued, want nothing without an address", err, len(queue.items))
+	}
+
+	rsvp.Email = "anna@example.com"
+	if err := service.ConfirmRSVP(rsvp, domain.GetDefaultPlanning(), "https://mariage.example.com/rsvp?lang=de&edit=abc"); err != nil {
+		t.Fatalf("ConfirmRSVP() error = %v", err)
+	}
+	if err := service.ProcessQueue(time.Now()); err != nil {
+		t.Fatalf("ProcessQueue() error = %v", err)
+	}
+	if len(mailer.sent) != 1 {
+		t.Fatalf("sent %d emails, want 1", len(mailer.sent))
+	}
+
+	// Confirmation dans la langue de l'invité, avec le programme joint
+	email := mailer.sent[0]
+	if email.To[0] != "anna@example.com" || email.Subject != "Eure Zusage ist bestätigt" {
+		t.Errorf("email = %+v", email)
+	}
+	for _, want := range []string{"Hallo Anna", "Erwachsene: 2", "Nacht vom 11. Juli", "Bis bald!", "https://mariage.example.com/rsvp?lang=de&edit=abc"} {
+		if !strings.Contains(email.Body, want) {
+			t.Errorf("Body = %q, want %q", email.Body, want)
+		}
+	}
+	if len(email.Attachments) != 1 || !strings.HasPrefix(email.Attachments[0].ContentType, "text/calendar") || !bytes.Contains(email.Attachments[0].Data, []byte("BEGIN:VCALENDAR")) {
+		t.Errorf("Attachments = %+v, want the calendar", email.Attachments)
+	}
+
+	// Un absent reçoit la confirmation sans calendrier, en français à défaut
+	absent, _ := domain.NewRSVP("Luc", "Martin", false, 0, 0, "", "")
+	absent.Email = "luc@example.com"
+	absent.Lang = "xx"
+	if err := service.ConfirmRSVP(absent, domain.GetDefaultPlanning(), "https://mariage.example.com/rsvp?edit=def"); err != nil {
+		t.Fatalf("ConfirmRSVP() error = %v", err)
+	}
+	service.ProcessQueue(time.Now())
+	if len(mailer.sent) != 2 {
+		t.Fatalf("sent %d emails, want 2", len(mailer.sent))
+	}
+	if email := mailer.sent[1]; email.Subject != "Nous avons bien reçu votre réponse" || len(email.Attachments) != 0 {
+		t.Errorf("email = %+v, want the French confirmation without attachment", email)
+	}
+}
+
+func TestInvitationService_Contact(t *testing.T) {
+	service := NewInvitationService(newMockInvitationStorage(nil))
+
+	invitation, err := service.CreateInvitation("Famille Müller", "famille", "muller@example.com", "DE")
+	if err != nil {
+		t.Fatalf("CreateInvitation() error = %v", err)
+	}
+	if invitation.Email != "muller@example.com" || invitation.Lang != "de" {
+		t.Errorf("CreateInvitation() = %+v, want the address and language", invitation)
+	}
+	if _, err := service.CreateInvitation("Famille Martin", "", "martin", ""); !errors.Is(err, domain.ErrInvalidEmail) {
+		t.Errorf("CreateInvitation() with invalid email error = %v, want ErrInvalidEmail", err)
+	}
+
+	// Une langue indisponible revient à la langue par défaut
+	if err := service.UpdateContact(invitation.ID, "", "xx"); err != nil {
+		t.Fatalf("UpdateContact() error = %v", err)
+	}
+	updated, _ := service.GetInvitation(invitation.ID)
+	if updated.Email != "" || updated.Lang != "" {
+		t.Errorf("UpdateContact() = %+v, want no address and the default language", updated)
+	}
+	if err := service.UpdateContact("inconnue", "a@example.com", ""); !errors.Is(err, ErrInvitationNotFound) {
+		t.Errorf("UpdateContact() with unknown ID error = %v, want ErrInvitationNotFound", err)
+	}
+}
+
+// Mock storage pour les campagnes de relance
+func newMockReminderCampaignStorage() *mockCollection[domain.ReminderCampaign] {
+	return newMockCollection(func(item *domain.ReminderCampaign) string { return item.ID }, ErrReminderCampaignNotFound, nil)
+}
+
+func TestReminderCampaignService(t *testing.T) {
+	invitations := newMockInvitationStorage([]*domain.Invitation{
+		{ID: "inv-1", Code: "abc23", Name: "Famille Müller", Email: "muller@example.com", Lang: "de"},
+		{ID: "inv-2", Code: "def45", Name: "Famille Dupont", Email: "dupont@example.com"},
+		{ID: "inv-3", Code: "ghj67", Name: "Famille Martin"}, // Sans adresse
+	})
+	rsvps := &mockStorage{rsvps: []*domain.RSVP{{ID: "rsvp-1", InvitationID: "inv-2"}}}
+	planningService := NewPlanningService()
+	queue := newMockEmailQueueStorage()
+	notificationService, err := NewNotificationService(&mockMailer{}, queue, NewCalendarService(nil), "../../web/templates/emails", nil, false, NotifyEachRSVP, 8*time.Hour, 10, "https://mariage.example.com")
+	if err != nil {
+		t.Fatalf("NewNotificationService() error = %v", err)
+	}
+	storage := newMockReminderCampaignStorage()
+	service := NewReminderCampaignService(storage, NewInvitationService(invitations), NewRSVPService(rsvps, planningService), planningService, notificationService, []int{7, 21}, 10*time.Hour, "https://mariage.example.com")
+
+	deadline := planningService.GetPlanning().RSVPDeadline
+	first, _ := domain.NewReminderCampaign(21)
+	second, _ := domain.NewReminderCampaign(7)
+
+	// Avant la première date, rien ne part
+	before := first.ScheduledAt(deadline, 10*time.Hour).Add(-time.Minute)
+	if err := service.ProcessDue(before); err != nil || len(queue.items) != 0 {
+		t.Fatalf("ProcessDue() = %v with %d queued, want nothing before the first campaign", err, len(queue.items))
+	}
+	statuses, err := service.Campaigns(before)
+	if err != nil {
+		t.Fatalf("Campaigns() error = %v", err)
+	}
+	if len(statuses) != 2 || statuses[0].ID != "j-21" || statuses[0].Pending != 1 || statuses[0].Missed {
+		t.Fatalf("Campaigns() = %+v, want j-21 first with one household to remind", statuses)
+	}
+
+	// Serveur arrêté pendant la première date : seule la plus récente part
+	now := second.ScheduledAt(deadline, 10*time.Hour).Add(time.Minute)
+	if err := service.ProcessDue(now); err != nil {
+		t.Fatalf("ProcessDue() error = %v", err)
+	}
+	if len(queue.items) != 1 {
+		t.Fatalf("queued %d emails, want a single reminder", len(queue.items))
+	}
+	email := queue.items[0].Email
+	if email.To[0] != "muller@example.com" || !strings.HasPrefix(email.Subject, "Kleine Erinnerung") {
+		t.Errorf("email = %+v, want the German reminder", email)
+	}
+	if !strings.Contains(email.Body, "https://mariage.example.com/rsvp?lang=de&invite=abc23") {
+		t.Errorf("Body = %q, want the personal link", email.Body)
+	}
+
+	statuses, _ = service.Campaigns(now)
+	if !statuses[0].Missed || statuses[1].RunAt.IsZero() || statuses[1].SentCount != 1 || statuses[1].Pending != 0 {
+		t.Errorf("Campaigns() = %+v, want j-21 missed and j-7 sent", statuses)
+	}
+
+	// Pas de nouvel envoi automatique de la même campagne
+	if err := service.ProcessDue(now.Add(time.Hour)); err != nil || len(queue.items) != 1 {
+		t.Errorf("ProcessDue() = %v with %d queued, want no duplicate", err, len(queue.items))
+	}
+
+	// Envoi manuel : seulement les foyers que la campagne n'a pas relancés
+	if sent, err := service.SendCampaign("j-21", now); err != nil || sent != 1 {
+		t.Errorf("SendCampaign(j-21) = %d, %v, want 1", sent, err)
+	}
+	if sent, err := service.SendCampaign("j-7", now); err != nil || sent != 0 {
+		t.Errorf("SendCampaign(j-7) = %d, %v, want 0", sent, err)
+	}
+	if _, err := service.SendCampaign("j-3", now); !errors.Is(err, ErrReminderCampaignNotFound) {
+		t.Errorf("SendCampaign(j-3) error = %v, want ErrReminderCampaignNotFound", err)
+	}
+
+	// Rien ne part après la date limite
+	storage.items = nil
+	after := deadline.Add(time.Hour)
+	if err := service.ProcessDue(after); err != nil || len(queue.items) != 2 {
+		t.Errorf("ProcessDue() = %v with %d queued, want nothing after the deadline", err, len(queue.items))
+	}
+	statuses, _ = service.Campaigns(after)
+	if !statuses[0].Expired || statuses[0].Missed {
+		t.Errorf("Campaigns() = %+v, want the campaigns expired", statuses)
+	}
+}
@@ -0,0 +1,152 @@
+package application
+
+import (
+	"errors"
+	"sort"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/domain/ports"
+)
+
+var (
+	ErrSongNotFound = errors.New("suggestion musicale introuvable")
+)
+
+// RankedSong regroupe les suggestions d'un même morceau
+type RankedSong struct {
+	Rank        int
+	Key         string
+	Title       string
+	Artist      string
+	Link        string // Premier lien d'écoute proposé
+	Suggestions []*domain.SongSuggestion
+}
+
+// Votes retourne le nombre d'invités ayant proposé le morceau
+func (s RankedSong) Votes() int {
+	return len(s.Suggestions)
+}
+
+// SongService gère les suggestions musicales des invités
+type SongService struct {
+	storage ports.SongStorage
+}
+
+// NewSongService crée un nouveau service de suggestions musicales
+func NewSongService(storage ports.SongStorage) *SongService {
+	return &SongService{
+		storage: storage,
+	}
+}
+
+// SuggestSong enregistre un morceau proposé par un invité, avec son RSVP
+// éventuel. Un foyer qui propose deux fois le même morceau n'est compté qu'une fois.
+func (s *SongService) SuggestSong(invitation *domain.Invitation, rsvpID, suggestedBy, title, artist, link string) (*domain.SongSuggestion, error) {
+	song, err := domain.NewSongSuggestion(suggestedBy, title, artist, link)
+	if err != nil {
+		return nil, err
+	}
+
+	if invitation != nil {
+		song.InvitationID = invitation.ID
+		if song.SuggestedBy == "" {
+			song.SuggestedBy = invitation.Name
+		}
+
+		own, err := s.SuggestionsOf(invitation)
+		if err != nil {
+			return nil, err
+		}
+		for _, existing := range own {
+			if existing.Key() == song.Key() {
+				return existing, nil
+			}
+		}
+	}
+
+	song.ID = generateID()
+	song.RSVPID = rsvpID
+
+	if err := s.storage.Save(song); err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	return song, nil
+}
+
+// SuggestionsOf retourne les morceaux proposés par le foyer invité
+func (s *SongService) SuggestionsOf(invitation *domain.Invitation) ([]*domain.SongSuggestion, error) {
+	songs, err := s.storage.FindAll()
+	if err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	var own []*domain.SongSuggestion
+	for _, song := range songs {
+		if invitation != nil && song.InvitationID == invitation.ID {
+			own = append(own, song)
+		}
+	}
+	return own, nil
+}
+
+// Ranking retourne les morceaux dédoublonnés, les plus demandés en premier
+// (à égalité, le premier proposé)
+func (s *SongService) Ranking() ([]RankedSong, error) {
+	songs, err := s.storage.FindAll()
+	if err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	sorted := make([]*domain.SongSuggestion, len(songs))
+	copy(sorted, songs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	var ranking []RankedSong
+	index := make(map[string]int)
+	for _, song := range sorted {
+		key := song.Key()
+		i, ok := index[key]
+		if !ok {
+			i = len(ranking)
+			index[key] = i
+			ranking = append(ranking, RankedSong{Key: key, Title: song.Title, Artist: song.Artist})
+		}
+		if ranking[i].Link == "" {
+			ranking[i].Link = song.Link
+		}
+		ranking[i].Suggestions = append(ranking[i].Suggestions, song)
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return ranking[i].Votes() > ranking[j].Votes()
+	})
+	for i := range ranking {
+		ranking[i].Rank = i + 1
+	}
+
+	return ranking, nil
+}
+
+// DeleteSong supprime toutes les suggestions d'un morceau (administration)
+func (s *SongService) DeleteSong(key string) error {
+	ranking, err := s.Ranking()
+	if err != nil {
+		return err
+	}
+
+	for _, ranked := range ranking {
+		if ranked.Key != key {
+			continue
+		}
+		for _, song := range ranked.Suggestions {
+			if err := s.storage.Delete(song.ID); err != nil {
+				return ErrStorageFailure
+			}
+		}
+		return nil
+	}
+
+	return ErrSongNotFound
+}
@@ -0,0 +1,177 @@
+package application
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"wedding-web/internal/domain"
+	"wedding-web/internal/domain/ports"
+)
+
+// ThankYouFilter filtre les foyers du suivi des remerciements
+type ThankYouFilter string
+
+const (
+	ThanksAll     ThankYouFilter = ""        // Tous les foyers
+	ThanksPending ThankYouFilter = "pending" // Pas encore remerciés
+	ThanksSent    ThankYouFilter = "sent"    // Carte envoyée
+)
+
+// ThankYouHousehold est un foyer à remercier : une invitation, ou une
+// réponse faite sans lien personnel
+type ThankYouHousehold struct {
+	InvitationID  string
+	RSVPID        string // Réponse sans invitation
+	Name          string
+	RSVP          *domain.RSVP     // Dernière réponse du foyer (nil : pas de réponse)
+	RegistryGifts []string         // Cadeaux réservés sur la liste de mariage
+	Record        *domain.ThankYou // Suivi enregistré (vide si rien n'est encore saisi)
+}
+
+// ThankYouService gère le suivi des remerciements après le mariage
+type ThankYouService struct {
+	storage           ports.ThankYouStorage
+	rsvpService       *RSVPService
+	invitationService *InvitationService
+	registryService   *RegistryService
+	mu                sync.Mutex // Sérialise les mises à jour (un seul suivi par foyer)
+}
+
+// NewThankYouService crée un nouveau service de suivi des remerciements
+func NewThankYouService(storage ports.ThankYouStorage, rsvpService *RSVPService, invitationService *InvitationService, registryService *RegistryService) *ThankYouService {
+	return &ThankYouService{
+		storage:           storage,
+		rsvpService:       rsvpService,
+		invitationService: invitationService,
+		registryService:   registryService,
+	}
+}
+
+// Households retourne les foyers triés par nom, avec leur réponse, les
+// cadeaux réservés sur la liste de mariage et le suivi des remerciements
+func (s *ThankYouService) Households(filter ThankYouFilter) ([]ThankYouHousehold, error) {
+	invitations, err := s.invitationService.ListInvitations()
+	if err != nil {
+		return nil, err
+	}
+	rsvps, err := s.rsvpService.LatestRSVPs()
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.registryService.ListItems()
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.storage.FindAll()
+	if err != nil {
+		return nil, ErrStorageFailure
+	}
+
+	byInvitation := map[string]*domain.RSVP{}
+	var households []ThankYouHousehold
+	for _, rsvp := range rsvps {
+		if rsvp.InvitationID != "" {
+			byInvitation[rsvp.InvitationID] = rsvp
+			continue
+		}
+		households = append(households, ThankYouHousehold{RSVPID: rsvp.ID, Name: rsvp.FullName(), RSVP: rsvp})
+	}
+	for _, invitation := range invitations {
+		households = append(households, ThankYouHousehold{InvitationID: invitation.ID, Name: invitation.Name, RSVP: byInvitation[invitation.ID]})
+	}
+
+	gifts := map[string][]string{}
+	for _, item := range items {
+		for _, reservation := range item.Reservations {
+			if reservation.InvitationID == "" {
+				continue
+			}
+			gift := item.Title
+			if item.Fund {
+				gift = fmt.Sprintf("%s (%d €)", item.Title, reservation.Amount)
+			}
+			gifts[reservation.InvitationID] = append(gifts[reservation.InvitationID], gift)
+		}
+	}
+
+	filtered := make([]ThankYouHousehold, 0, len(households))
+	for _, household := range households {
+		household.Record = findThankYou(records, household.InvitationID, household.RSVPID)
+		if household.Record == nil {
+			household.Record, _ = domain.NewThankYou(household.InvitationID, household.RSVPID)
+		}
+		if household.InvitationID != "" {
+			household.RegistryGifts = gifts[household.InvitationID]
+		}
+
+		if (filter == ThanksPending && household.Record.IsSent()) || (filter == ThanksSent && !household.Record.IsSent()) {
+			continue
+		}
+		filtered = append(filtered, household)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return strings.ToLower(filtered[i].Name) < strings.ToLower(filtered[j].Name)
+	})
+	return filtered, nil
+}
+
+// Save enregistre le cadeau reçu, la note et l'adresse postale d'un foyer
+func (s *ThankYouService) Save(invitationID, rsvpID, gift, note string, address domain.PostalAddress) error {
+	return s.update(invitationID, rsvpID, func(thanks *domain.ThankYou) error {
+		return thanks.Update(gift, note, address)
+	})
+}
+
+// MarkSent indique que la carte de remerciement d'un foyer a été envoyée
+// aujourd'hui, ou annule l'envoi
+func (s *ThankYouService) MarkSent(invitationID, rsvpID string, sent bool) error {
+	return s.update(invitationID, rsvpID, func(thanks *domain.ThankYou) error {
+		at := time.Time{}
+		if sent {
+			at = time.Now()
+		}
+		thanks.MarkSent(at)
+		return nil
+	})
+}
+
+// update modifie le suivi d'un foyer, créé au premier enregistrement
+func (s *ThankYouService) update(invitationID, rsvpID string, change func(*domain.ThankYou) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.storage.FindAll()
+	if err != nil {
+		return ErrStorageFailure
+	}
+
+	thanks := findThankYou(records, invitationID, rsvpID)
+	if thanks == nil {
+		if thanks, err = domain.NewThankYou(invitationID, rsvpID); err != nil {
+			return err
+		}
+		thanks.ID = generateID()
+	}
+
+	if err := change(thanks); err != nil {
+		return err
+	}
+
+	if err := s.storage.Save(thanks); err != nil {
+		return ErrStorageFailure
+	}
+	return nil
+}
+
+// findThankYou retourne le suivi enregistré pour un foyer (nil s'il n'existe pas)
+func findThankYou(records []*domain.ThankYou, invitationID, rsvpID string) *domain.ThankYou {
+	for _, thanks := range records {
+		if (invitationID != "" && thanks.InvitationID == invitationID) || (rsvpID != "" && thanks.RSVPID == rsvpID) {
+			return thanks
+		}
+	}
+	return nil
+}
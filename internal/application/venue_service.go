@@ -0,0 +1,180 @@
+package application
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"wedding-web/internal/domain"
+)
+
+// Dimensions du plan statique (unités SVG)
+const (
+	staticMapWidth   = 600
+	staticMapHeight  = 360
+	staticMapPadding = 60
+	// Écart minimal entre deux repères pour qu'ils restent lisibles
+	staticMapMinMarkerGap = 40
+)
+
+// StaticMap est un plan schématique des lieux, calculé à partir des
+// coordonnées locales et rendu en SVG (aucune tuile externe n'est chargée)
+type StaticMap struct {
+	Width   int
+	Height  int
+	Markers []MapMarker
+}
+
+// MapMarker est un repère numéroté du plan
+type MapMarker struct {
+	Number      int
+	Venue       *domain.Venue
+	X           float64
+	Y           float64
+	LabelAnchor string // "start" ou "end" selon le côté du plan
+}
+
+// VenueService construit les liens d'itinéraire et le plan des lieux
+type VenueService struct{}
+
+// NewVenueService crée un nouveau service des lieux
+func NewVenueService() *VenueService {
+	return &VenueService{}
+}
+
+// OpenStreetMapDirectionsURL retourne le lien d'itinéraire OpenStreetMap vers un lieu
+func (s *VenueService) OpenStreetMapDirectionsURL(venue *domain.Venue) string {
+	if venue == nil {
+		return ""
+	}
+	if !venue.HasCoordinates() {
+		return "https://www.openstreetmap.org/search?" + encodeQuery(url.Values{"query": {venue.Address}})
+	}
+	params := url.Values{}
+	params.Set("route", ";"+formatLatLon(venue))
+	return "https://www.openstreetmap.org/directions?" + encodeQuery(params)
+}
+
+// GoogleMapsDirectionsURL retourne le lien d'itinéraire Google Maps vers un lieu
+func (s *VenueService) GoogleMapsDirectionsURL(venue *domain.Venue) string {
+	if venue == nil {
+		return ""
+	}
+	destination := venue.Address
+	if venue.HasCoordinates() {
+		destination = formatLatLon(venue)
+	}
+	params := url.Values{}
+	params.Set("api", "1")
+	params.Set("destination", destination)
+	return "https://www.google.com/maps/dir/?" + encodeQuery(params)
+}
+
+// StaticMap calcule le plan des lieux géolocalisés.
+// Retourne nil si aucun lieu n'a de coordonnées.
+func (s *VenueService) StaticMap(venues []*domain.Venue) *StaticMap {
+	var located []*domain.Venue
+	for _, venue := range venues {
+		if venue.HasCoordinates() {
+			located = append(located, venue)
+		}
+	}
+	if len(located) == 0 {
+		return nil
+	}
+
+	// Projection équirectangulaire centrée sur la zone : suffisante à
+	// l'échelle de quelques kilomètres
+	minLat, maxLat := located[0].Latitude, located[0].Latitude
+	minLon, maxLon := located[0].Longitude, located[0].Longitude
+	for _, venue := range located[1:] {
+		minLat = math.Min(minLat, venue.Latitude)
+		maxLat = math.Max(maxLat, venue.Latitude)
+		minLon = math.Min(minLon, venue.Longitude)
+		maxLon = math.Max(maxLon, venue.Longitude)
+	}
+	lonScale := math.Cos((minLat + maxLat) / 2 * math.Pi / 180)
+
+	spanX := (maxLon - minLon) * lonScale
+	spanY := maxLat - minLat
+	innerWidth := float64(staticMapWidth - 2*staticMapPadding)
+	innerHeight := float64(staticMapHeight - 2*staticMapPadding)
+
+	scale := 0.0
+	if spanX > 0 || spanY > 0 {
+		scale = math.Min(innerWidth/math.Max(spanX, 1e-9), innerHeight/math.Max(spanY, 1e-9))
+	}
+
+	// Centrer le nuage de points dans le plan
+	offsetX := staticMapPadding + (innerWidth-spanX*scale)/2
+	offsetY := staticMapPadding + (innerHeight-spanY*scale)/2
+
+	markers := make([]MapMarker, len(located))
+	for i, venue := range located {
+		markers[i] = MapMarker{
+			Number: i + 1,
+			Venue:  venue,
+			X:      offsetX + (venue.Longitude-minLon)*lonScale*scale,
+			Y:      offsetY + (maxLat-venue.Latitude)*scale,
+		}
+	}
+
+	spreadMarkers(markers)
+
+	for i := range markers {
+		markers[i].LabelAnchor = "start"
+		if markers[i].X > staticMapWidth/2 {
+			markers[i].LabelAnchor = "end"
+		}
+	}
+
+	return &StaticMap{
+		Width:   staticMapWidth,
+		Height:  staticMapHeight,
+		Markers: markers,
+	}
+}
+
+// spreadMarkers écarte les repères trop proches les uns des autres
+// (lieux voisins de quelques centaines de mètres) pour qu'ils restent lisibles
+func spreadMarkers(markers []MapMarker) {
+	for iteration := 0; iteration < 20; iteration++ {
+		moved := false
+		for i := range markers {
+			for j := i + 1; j < len(markers); j++ {
+				dx := markers[j].X - markers[i].X
+				dy := markers[j].Y - markers[i].Y
+				distance := math.Hypot(dx, dy)
+				if distance >= staticMapMinMarkerGap {
+					continue
+				}
+				if distance == 0 {
+					dx, dy, distance = 0, 1, 1
+				}
+				push := (staticMapMinMarkerGap - distance) / 2
+				markers[i].X -= dx / distance * push
+				markers[i].Y -= dy / distance * push
+				markers[j].X += dx / distance * push
+				markers[j].Y += dy / distance * push
+				moved = true
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+
+	for i := range markers {
+		markers[i].X = clamp(markers[i].X, staticMapPadding/2, staticMapWidth-staticMapPadding/2)
+		markers[i].Y = clamp(markers[i].Y, staticMapPadding/2, staticMapHeight-staticMapPadding/2)
+	}
+}
+
+// clamp borne une valeur dans l'intervalle [min, max]
+func clamp(v, min, max float64) float64 {
+	return math.Max(min, math.Min(max, v))
+}
+
+// formatLatLon formate les coordonnées d'un lieu ("lat,lon")
+func formatLatLon(venue *domain.Venue) string {
+	return fmt.Sprintf("%.6f,%.6f", venue.Latitude, venue.Longitude)
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidAccommodation = errors.New("hébergement invalide")
+	ErrInvalidNights        = errors.New("nuits d'hébergement invalides")
+)
+
+// NightDateFormat est le format des nuits d'hébergement (date du soir)
+const NightDateFormat = "2006-01-02"
+
+// Accommodation représente un hébergement recommandé aux invités
+type Accommodation struct {
+	ID            string
+	Name          string
+	Kind          string // Ex: "Hôtel", "Gîte", "Chambres d'hôtes"
+	Address       string
+	DistanceKm    float64 // Distance du lieu de réception
+	PriceRange    string  // Ex: "€€" ou "90-120 € la nuit"
+	Website       string
+	Phone         string
+	BookingCode   string    // Code à mentionner pour bénéficier du bloc de chambres
+	RoomsBlocked  int       // Chambres réservées pour le mariage (0: aucun bloc)
+	BlockDeadline time.Time // Date de libération des chambres non réservées
+	Notes         string
+}
+
+// Validate vérifie qu'un hébergement est exploitable
+func (a Accommodation) Validate() error {
+	if strings.TrimSpace(a.Name) == "" || a.DistanceKm < 0 || a.RoomsBlocked < 0 {
+		return ErrInvalidAccommodation
+	}
+	if a.Website != "" && !strings.HasPrefix(a.Website, "https://") && !strings.HasPrefix(a.Website, "http://") {
+		return ErrInvalidAccommodation
+	}
+	return nil
+}
+
+// HasRoomBlock indique si des chambres sont réservées pour le mariage
+func (a Accommodation) HasRoomBlock() bool {
+	return a.RoomsBlocked > 0
+}
+
+// BlockExpired indique si le bloc de chambres a été libéré à la date donnée
+func (a Accommodation) BlockExpired(now time.Time) bool {
+	return !a.BlockDeadline.IsZero() && now.After(a.BlockDeadline.AddDate(0, 0, 1))
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAccommodation_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		accommodation Accommodation
+		wantErr       bool
+	}{
+		{"valid", Accommodation{Name: "Hôtel de Londres", Website: "https://exemple.com", RoomsBlocked: 8}, false},
+		{"missing name", Accommodation{Name: " "}, true},
+		{"negative distance", Accommodation{Name: "Gîte", DistanceKm: -1}, true},
+		{"unsafe website", Accommodation{Name: "Gîte", Website: "javascript:alert(1)"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.accommodation.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccommodation_BlockExpired(t *testing.T) {
+	accommodation := Accommodation{Name: "Hôtel", RoomsBlocked: 5, BlockDeadline: time.Date(2026, 5, 15, 0, 0, 0, 0, time.UTC)}
+
+	if accommodation.BlockExpired(time.Date(2026, 5, 15, 18, 0, 0, 0, time.UTC)) {
+		t.Error("Block should still be available on its deadline")
+	}
+	if !accommodation.BlockExpired(time.Date(2026, 5, 16, 1, 0, 0, 0, time.UTC)) {
+		t.Error("Block should be expired after its deadline")
+	}
+}
+
+func TestRSVP_SetAccommodationNights(t *testing.T) {
+	allowed := GetDefaultPlanning().AccommodationNights()
+
+	rsvp, _ := NewRSVP("Jean", "Dupont", true, 2, 0, "", "")
+	if err := rsvp.SetAccommodationNights([]string{"2026-07-11", "2026-07-10", "2026-07-10"}, allowed); err != nil {
+		t.Fatalf("SetAccommodationNights() error = %v", err)
+	}
+	if len(rsvp.AccommodationNights) != 2 || rsvp.AccommodationNights[0] != "2026-07-10" {
+		t.Errorf("AccommodationNights = %v, want [2026-07-10 2026-07-11]", rsvp.AccommodationNights)
+	}
+	if !rsvp.NeedsAccommodationOn(allowed[1]) || rsvp.NeedsAccommodationOn(allowed[2]) {
+		t.Error("NeedsAccommodationOn() does not match the requested nights")
+	}
+
+	if err := rsvp.SetAccommodationNights([]string{"2026-08-01"}, allowed); err != ErrInvalidNights {
+		t.Errorf("SetAccommodationNights() error = %v, want %v", err, ErrInvalidNights)
+	}
+	if rsvp.NeedsAccommodation() {
+		t.Error("Invalid nights should not be kept")
+	}
+
+	// Les absents n'ont pas besoin d'hébergement
+	absent, _ := NewRSVP("Marie", "Martin", false, 0, 0, "", "")
+	if err := absent.SetAccommodationNights([]string{"2026-07-11"}, allowed); err != nil || absent.NeedsAccommodation() {
+		t.Errorf("SetAccommodationNights() on absent = %v, nights %v", err, absent.AccommodationNights)
+	}
+}
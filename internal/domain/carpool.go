@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCarpool = errors.New("annonce de covoiturage invalide")
+	ErrInvalidSeats   = errors.New("nombre de places invalide")
+	ErrInvalidContact = errors.New("contact invalide")
+)
+
+// CarpoolKind distingue les offres des demandes de places
+type CarpoolKind string
+
+const (
+	CarpoolOffer   CarpoolKind = "offer"
+	CarpoolRequest CarpoolKind = "request"
+)
+
+// CarpoolLeg est un trajet entre deux événements du planning ayant lieu
+// à des endroits différents
+type CarpoolLeg struct {
+	ID   string
+	From PlanningEvent
+	To   PlanningEvent
+}
+
+// In retourne le trajet avec les textes de ses événements dans la langue donnée
+func (l CarpoolLeg) In(lang string) CarpoolLeg {
+	l.From = l.From.In(lang)
+	l.To = l.To.In(lang)
+	return l
+}
+
+// CarpoolEntry est une offre ou une demande de places sur un trajet
+type CarpoolEntry struct {
+	ID           string      `json:"id"`
+	InvitationID string      `json:"invitation_id"` // Foyer auteur de l'annonce
+	Kind         CarpoolKind `json:"kind"`
+	LegID        string      `json:"leg_id"`
+	Name         string      `json:"name"`
+	Contact      string      `json:"contact"` // Téléphone ou e-mail, visible des seuls invités
+	Seats        int         `json:"seats"`   // Places offertes ou demandées
+	Notes        string      `json:"notes"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+// NewCarpoolEntry crée une annonce de covoiturage avec validation
+func NewCarpoolEntry(kind CarpoolKind, legID, name, contact string, seats int, notes string) (*CarpoolEntry, error) {
+	if kind != CarpoolOffer && kind != CarpoolRequest {
+		return nil, ErrInvalidCarpool
+	}
+
+	legID = strings.TrimSpace(legID)
+	if legID == "" {
+		return nil, ErrInvalidCarpool
+	}
+
+	name = strings.TrimSpace(name)
+	if len(name) == 0 || len(name) > 100 {
+		return nil, ErrInvalidName
+	}
+
+	contact = strings.TrimSpace(contact)
+	if len(contact) < 3 || len(contact) > 100 {
+		return nil, ErrInvalidContact
+	}
+
+	if seats < 1 || seats > 8 {
+		return nil, ErrInvalidSeats
+	}
+
+	notes = strings.TrimSpace(notes)
+	if len(notes) > 500 {
+		return nil, ErrMessageTooLong
+	}
+
+	return &CarpoolEntry{
+		Kind:      kind,
+		LegID:     legID,
+		Name:      name,
+		Contact:   contact,
+		Seats:     seats,
+		Notes:     notes,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// IsOffer indique si l'annonce propose des places
+func (e *CarpoolEntry) IsOffer() bool {
+	return e.Kind == CarpoolOffer
+}
+
+// CarpoolLegs retourne les trajets entre événements successifs qui changent de lieu
+func (p *Planning) CarpoolLegs() []CarpoolLeg {
+	var legs []CarpoolLeg
+	var previous *PlanningEvent
+
+	for i := range p.Events {
+		event := &p.Events[i]
+		if event.Venue == nil || event.HideLocation {
+			continue
+		}
+		if previous != nil && previous.Venue.ID != event.Venue.ID {
+			legs = append(legs, CarpoolLeg{
+				ID:   previous.ID + "--" + event.ID,
+				From: *previous,
+				To:   *event,
+			})
+		}
+		previous = event
+	}
+
+	return legs
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "testing"
+
+func TestNewCarpoolEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    CarpoolKind
+		contact string
+		seats   int
+		wantErr error
+	}{
+		{"valid offer", CarpoolOffer, "06 12 34 56 78", 3, nil},
+		{"valid request", CarpoolRequest, "jean@exemple.com", 2, nil},
+		{"unknown kind", CarpoolKind("taxi"), "06 12 34 56 78", 1, ErrInvalidCarpool},
+		{"missing contact", CarpoolOffer, " ", 1, ErrInvalidContact},
+		{"no seats", CarpoolRequest, "06 12 34 56 78", 0, ErrInvalidSeats},
+		{"too many seats", CarpoolOffer, "06 12 34 56 78", 9, ErrInvalidSeats},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCarpoolEntry(tt.kind, "leg", "Jean Dupont", tt.contact, tt.seats, "")
+			if err != tt.wantErr {
+				t.Errorf("NewCarpoolEntry() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPlanning_CarpoolLegs(t *testing.T) {
+	legs := GetDefaultPlanning().CarpoolLegs()
+
+	if len(legs) == 0 {
+		t.Fatal("CarpoolLegs() returned no leg")
+	}
+	for _, leg := range legs {
+		if leg.From.Venue.ID == leg.To.Venue.ID {
+			t.Errorf("Leg %s does not change venue", leg.ID)
+		}
+		if leg.ID != leg.From.ID+"--"+leg.To.ID {
+			t.Errorf("Leg ID = %s, want %s--%s", leg.ID, leg.From.ID, leg.To.ID)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidEmail = errors.New("email invalide")
+)
+
+// Délais entre deux tentatives d'envoi d'un email : doublés à chaque échec,
+// sans dépasser maxRetryDelay
+const (
+	firstRetryDelay = time.Minute
+	maxRetryDelay   = 6 * time.Hour
+)
+
+// Email est un message texte à envoyer, avec d'éventuelles pièces jointes
+type Email struct {
+	To          []string     `json:"to"`
+	Subject     string       `json:"subject"`
+	Body        string       `json:"body"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// Attachment est une pièce jointe d'un email
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"` // Ex: "text/calendar; charset=utf-8"
+	Data        []byte `json:"data"`
+}
+
+// QueuedEmail est un email en attente d'envoi, conservé jusqu'à ce qu'il
+// parte : un échec d'envoi le reprogramme plus tard
+type QueuedEmail struct {
+	ID          string    `json:"id"`
+	Email       Email     `json:"email"`
+	Digest      bool      `json:"digest,omitempty"` // Regroupé avec les autres dans le récapitulatif quotidien
+	Attempts    int       `json:"attempts"`
+	LastError   string    `json:"last_error,omitempty"`
+	NextAttempt time.Time `json:"next_attempt"` // Date d'envoi prévue (heure du récapitulatif, ou nouvel essai)
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewQueuedEmail prépare l'envoi d'un email à partir de la date donnée
+func NewQueuedEmail(email Email, sendAt time.Time) (*QueuedEmail, error) {
+	if len(email.To) == 0 || strings.TrimSpace(email.Subject) == "" || strings.TrimSpace(email.Body) == "" {
+		return nil, ErrInvalidEmail
+	}
+	for _, to := range email.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return nil, ErrInvalidEmail
+		}
+	}
+
+	return &QueuedEmail{
+		Email:       email,
+		NextAttempt: sendAt,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// NormalizeEmail vérifie une adresse saisie par un invité ou les mariés :
+// une adresse simple (sans nom affiché), ou vide si elle n'est pas donnée
+func NormalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return "", nil
+	}
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || len(email) > 254 {
+		return "", ErrInvalidEmail
+	}
+	return email, nil
+}
+
+// IsDue indique si l'email doit partir à la date donnée
+func (q *QueuedEmail) IsDue(now time.Time) bool {
+	return !now.Before(q.NextAttempt)
+}
+
+// Failed enregistre un échec d'envoi et reprogramme l'email
+func (q *QueuedEmail) Failed(err error, now time.Time) {
+	q.Attempts++
+	q.LastError = err.Error()
+
+	delay := firstRetryDelay << (q.Attempts - 1)
+	if q.Attempts > 16 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	q.NextAttempt = now.Add(delay)
+}
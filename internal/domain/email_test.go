@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewQueuedEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		email   Email
+		wantErr error
+	}{
+		{"valid", Email{To: []string{"marie@example.com", "Paul <paul@example.com>"}, Subject: "Réponse", Body: "Texte"}, nil},
+		{"no recipient", Email{Subject: "Réponse", Body: "Texte"}, ErrInvalidEmail},
+		{"invalid recipient", Email{To: []string{"marie"}, Subject: "Réponse", Body: "Texte"}, ErrInvalidEmail},
+		{"no subject", Email{To: []string{"marie@example.com"}, Subject: " ", Body: "Texte"}, ErrInvalidEmail},
+		{"no body", Email{To: []string{"marie@example.com"}, Subject: "Réponse"}, ErrInvalidEmail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewQueuedEmail(tt.email, time.Now()); err != tt.wantErr {
+				t.Errorf("NewQueuedEmail() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQueuedEmailFailed(t *testing.T) {
+	now := time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+	email, _ := NewQueuedEmail(Email{To: []string{"marie@example.com"}, Subject: "Réponse", Body: "Texte"}, now)
+	if !email.IsDue(now) {
+		t.Fatal("IsDue() = false, want true at the planned date")
+	}
+
+	// Délai doublé à chaque échec, plafonné
+	for _, want := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute} {
+		email.Failed(errors.New("refusé"), now)
+		if got := email.NextAttempt.Sub(now); got != want {
+			t.Errorf("attempt %d: delay = %v, want %v", email.Attempts, got, want)
+		}
+	}
+	for i := 0; i < 30; i++ {
+		email.Failed(errors.New("refusé"), now)
+	}
+	if got := email.NextAttempt.Sub(now); got != maxRetryDelay {
+		t.Errorf("delay = %v, want %v", got, maxRetryDelay)
+	}
+	if email.IsDue(now) || email.LastError != "refusé" {
+		t.Errorf("email = %+v, want rescheduled with the last error", email)
+	}
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyMessage     = errors.New("message vide")
+	ErrForbiddenContent = errors.New("contenu inapproprié")
+	ErrLinkNotAllowed   = errors.New("liens non autorisés")
+)
+
+// GuestbookStatus représente l'état de modération d'un message
+type GuestbookStatus string
+
+const (
+	GuestbookPending  GuestbookStatus = "pending"  // En attente de modération
+	GuestbookApproved GuestbookStatus = "approved" // Visible sur le site
+)
+
+// GuestbookEntry est un message laissé par un invité dans le livre d'or
+type GuestbookEntry struct {
+	ID           string          `json:"id"`
+	InvitationID string          `json:"invitation_id,omitempty"` // Foyer auteur (vide: invité non identifié)
+	Name         string          `json:"name"`
+	Message      string          `json:"message"`
+	Status       GuestbookStatus `json:"status"`
+	CreatedAt    time.Time       `json:"created_at"`
+	ApprovedAt   time.Time       `json:"approved_at,omitempty"`
+	IPAddress    string          `json:"-"` // Ne pas persister l'IP
+}
+
+// linkPattern repère les adresses web, y compris sans schéma ("exemple.com")
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.|\b[a-z0-9-]+\.(com|net|org|fr|de|ch|be|eu|io|info|biz|ru|xyz|top)\b)`)
+
+// forbiddenWords liste les grossièretés refusées, sans accents ni majuscules
+// (français, allemand, anglais)
+var forbiddenWords = map[string]bool{
+	"connard": true, "connasse": true, "salope": true, "encule": true,
+	"pute": true, "merde": true, "batard": true, "nique": true,
+	"arschloch": true, "scheisse": true, "scheiße": true, "schlampe": true, "wichser": true, "fotze": true,
+	"fuck": true, "shit": true, "bitch": true, "asshole": true, "cunt": true,
+}
+
+// NewGuestbookEntry crée un message du livre d'or en attente de modération.
+// Les liens et les grossièretés sont refusés.
+func NewGuestbookEntry(name, message string) (*GuestbookEntry, error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 || len(name) > 100 {
+		return nil, ErrInvalidName
+	}
+
+	message = strings.TrimSpace(message)
+	if len(message) == 0 {
+		return nil, ErrEmptyMessage
+	}
+	if len(message) > 1000 {
+		return nil, ErrMessageTooLong
+	}
+
+	if linkPattern.MatchString(name) || linkPattern.MatchString(message) {
+		return nil, ErrLinkNotAllowed
+	}
+	if containsForbiddenWord(name) || containsForbiddenWord(message) {
+		return nil, ErrForbiddenContent
+	}
+
+	return &GuestbookEntry{
+		Name:      name,
+		Message:   message,
+		Status:    GuestbookPending,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// IsApproved indique si le message est visible sur le site
+func (e *GuestbookEntry) IsApproved() bool {
+	return e.Status == GuestbookApproved
+}
+
+// Approve publie le message sur le site
+func (e *GuestbookEntry) Approve(now time.Time) {
+	e.Status = GuestbookApproved
+	e.ApprovedAt = now
+}
+
+// containsForbiddenWord indique si le texte contient une grossièreté,
+// quels que soient la casse et les accents
+func containsForbiddenWord(text string) bool {
+	for _, word := range strings.Fields(normalizeText(text)) {
+		if forbiddenWords[word] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewGuestbookEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		author  string
+		message string
+		wantErr error
+	}{
+		{"valid", "Jean", "Tous nos vœux de bonheur !", nil},
+		{"valid with dots", "Marie", "Bravo... et à bientôt. Vive les mariés", nil},
+		{"missing name", " ", "Félicitations", ErrInvalidName},
+		{"empty message", "Jean", "  ", ErrEmptyMessage},
+		{"link", "Jean", "Voir https://example.com", ErrLinkNotAllowed},
+		{"link without scheme", "Jean", "Promo sur exemple.com !", ErrLinkNotAllowed},
+		{"www link", "Jean", "www.exemple", ErrLinkNotAllowed},
+		{"profanity", "Jean", "Quelle MERDE", ErrForbiddenContent},
+		{"accented profanity", "Jean", "Enculé", ErrForbiddenContent},
+		{"german profanity", "Hans", "So eine Scheiße", ErrForbiddenContent},
+		{"word containing a forbidden word", "Jean", "Une belle journée à Montputeaux", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := NewGuestbookEntry(tt.author, tt.message)
+			if err != tt.wantErr {
+				t.Errorf("NewGuestbookEntry() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && entry.IsApproved() {
+				t.Error("NewGuestbookEntry() should be pending moderation")
+			}
+		})
+	}
+}
+
+func TestGuestbookEntry_Approve(t *testing.T) {
+	entry, _ := NewGuestbookEntry("Jean", "Félicitations")
+	now := time.Date(2026, 7, 12, 10, 0, 0, 0, time.UTC)
+	entry.Approve(now)
+
+	if !entry.IsApproved() || !entry.ApprovedAt.Equal(now) {
+		t.Errorf("Approve() = %+v", entry)
+	}
+}
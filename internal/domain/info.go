@@ -1,31 +1,69 @@
 package domain
 
-// Info représente une information pratique
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidPracticalInfo = errors.New("infos pratiques invalides")
+)
+
+// AccommodationsAnchor est l'ancre réservée à l'annuaire des hébergements
+// sur /infos ; aucune section ne peut la reprendre.
+const AccommodationsAnchor = "annuaire-hebergements"
+
+// Info représente une section d'informations pratiques
 type Info struct {
-	Title   string
-	Content string
-	Icon    string // optionnel, pour le style
+	ID       string // Ancre de la section (optionnel, ex: "acces")
+	Title    string
+	Content  string   // Corps en Markdown
+	Icon     string   // optionnel, pour le style
+	Audience []string // Groupes invités (vide: tous les invités)
+}
+
+// IsVisibleTo indique si l'information concerne l'invitation donnée
+func (i Info) IsVisibleTo(invitation *Invitation) bool {
+	return isVisibleTo(i.Audience, invitation)
 }
 
 // PracticalInfo contient toutes les informations pratiques
 type PracticalInfo struct {
-	Venue         Info
-	Access        Info
-	Parking       Info
-	Accommodation Info
-	DressCode     Info
-	MapURL        string
+	Sections []Info   // Sections affichées dans l'ordre
+	Venues   []*Venue // Lieux présentés avec plan et itinéraires
 }
 
-// GetDefaultPracticalInfo retourne les infos pratiques par défaut
-func GetDefaultPracticalInfo() *PracticalInfo {
+// Validate vérifie que chaque section a un titre et que les ancres sont
+// uniques et distinctes de l'ancre réservée à l'annuaire des hébergements
+func (p *PracticalInfo) Validate() error {
+	ids := make(map[string]bool)
+	for _, section := range p.Sections {
+		if strings.TrimSpace(section.Title) == "" {
+			return ErrInvalidPracticalInfo
+		}
+		if section.ID == "" {
+			continue
+		}
+		if ids[section.ID] || section.ID == AccommodationsAnchor {
+			return ErrInvalidPracticalInfo
+		}
+		ids[section.ID] = true
+	}
+	return nil
+}
+
+// VisibleTo retourne une copie des infos pratiques sans les sections
+// réservées à d'autres groupes
+func (p *PracticalInfo) VisibleTo(invitation *Invitation) *PracticalInfo {
+	sections := make([]Info, 0, len(p.Sections))
+	for _, section := range p.Sections {
+		if section.IsVisibleTo(invitation) {
+			sections = append(sections, section)
+		}
+	}
+
 	return &PracticalInfo{
-		Venue: Info{
-			Title: "Le lieu",
-			Content: `Le mariage civil aura lieu à la mairie de Cély.
-La cérémonie laïque se tiendra ensuite chez nous, au 8 rue du Bois Beaudoin, à Cély-en-Bière.
-La soirée se poursuivra à la Bergerie de Villiers-en-Bière.
-Adresse : rue de la Bascule, 77190 Villiers-en-Bière.`,
-		},
+		Sections: sections,
+		Venues:   p.Venues,
 	}
 }
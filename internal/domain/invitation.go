@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidInvitation = errors.New("invitation invalide")
+)
+
+// Invitation représente un foyer invité, identifié par un code personnel
+type Invitation struct {
+	ID        string    `json:"id"`
+	Code      string    `json:"code"`            // Code secret transmis à l'invité (lien personnel)
+	Name      string    `json:"name"`            // Ex: "Famille Dupont"
+	Groups    []string  `json:"groups"`          // Groupes d'audience (ex: "famille", "temoins")
+	Email     string    `json:"email,omitempty"` // Adresse du foyer, pour les relances (facultative)
+	Lang      string    `json:"lang,omitempty"`  // Langue des emails du foyer (vide : langue par défaut)
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewInvitation crée une nouvelle invitation avec validation
+func NewInvitation(name string, groups []string) (*Invitation, error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 || len(name) > 100 {
+		return nil, ErrInvalidInvitation
+	}
+
+	return &Invitation{
+		Name:      name,
+		Groups:    NormalizeGroups(groups),
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// SetEmail enregistre l'adresse du foyer (vide : pas de relance par email)
+func (i *Invitation) SetEmail(email string) error {
+	email, err := NormalizeEmail(email)
+	if err != nil {
+		return err
+	}
+	i.Email = email
+	return nil
+}
+
+// InGroup indique si l'invitation appartient au groupe donné
+func (i *Invitation) InGroup(group string) bool {
+	group = strings.ToLower(strings.TrimSpace(group))
+	for _, g := range i.Groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// isVisibleTo applique la règle d'audience commune aux contenus du site :
+// une audience vide est publique, sinon l'invitation doit appartenir à
+// l'un des groupes
+func isVisibleTo(audience []string, invitation *Invitation) bool {
+	if len(audience) == 0 {
+		return true
+	}
+	if invitation == nil {
+		return false
+	}
+	for _, group := range audience {
+		if invitation.InGroup(group) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeGroups met les groupes en minuscules et supprime les vides et doublons
+func NormalizeGroups(groups []string) []string {
+	normalized := make([]string, 0, len(groups))
+	seen := make(map[string]bool)
+	for _, g := range groups {
+		g = strings.ToLower(strings.TrimSpace(g))
+		if g == "" || seen[g] {
+			continue
+		}
+		seen[g] = true
+		normalized = append(normalized, g)
+	}
+	return normalized
+}
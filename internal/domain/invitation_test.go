@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestNewInvitation(t *testing.T) {
+	invitation, err := NewInvitation("  Famille Dupont ", []string{"Famille", " temoins", "", "famille"})
+	if err != nil {
+		t.Fatalf("NewInvitation() unexpected error = %v", err)
+	}
+
+	if invitation.Name != "Famille Dupont" {
+		t.Errorf("Name = %q, want %q", invitation.Name, "Famille Dupont")
+	}
+
+	if len(invitation.Groups) != 2 || invitation.Groups[0] != "famille" || invitation.Groups[1] != "temoins" {
+		t.Errorf("Groups = %v, want [famille temoins]", invitation.Groups)
+	}
+
+	if _, err := NewInvitation("", nil); err != ErrInvalidInvitation {
+		t.Errorf("NewInvitation(\"\") error = %v, want %v", err, ErrInvalidInvitation)
+	}
+}
+
+func TestPlanningEvent_IsVisibleTo(t *testing.T) {
+	family := &Invitation{Name: "Famille Dupont", Groups: []string{"famille"}}
+	friends := &Invitation{Name: "Les amis", Groups: []string{"amis"}}
+
+	public := PlanningEvent{Title: "Vin d'honneur"}
+	restricted := PlanningEvent{Title: "Cérémonie civile", Audience: []string{"Famille"}}
+
+	tests := []struct {
+		name       string
+		event      PlanningEvent
+		invitation *Invitation
+		want       bool
+	}{
+		{"Public event, anonymous", public, nil, true},
+		{"Public event, invited", public, friends, true},
+		{"Restricted event, anonymous", restricted, nil, false},
+		{"Restricted event, other group", restricted, friends, false},
+		{"Restricted event, member", restricted, family, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.event.IsVisibleTo(tt.invitation); got != tt.want {
+				t.Errorf("IsVisibleTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanning_VisibleTo(t *testing.T) {
+	planning := GetDefaultPlanning()
+	planning.Events[0].Audience = []string{"famille"}
+
+	anonymous := planning.VisibleTo(nil)
+	if len(anonymous.Events) != len(planning.Events)-1 {
+		t.Errorf("VisibleTo(nil) returned %d events, want %d", len(anonymous.Events), len(planning.Events)-1)
+	}
+
+	family := planning.VisibleTo(&Invitation{Groups: []string{"famille"}})
+	if len(family.Events) != len(planning.Events) {
+		t.Errorf("VisibleTo(family) returned %d events, want %d", len(family.Events), len(planning.Events))
+	}
+}
+
+func TestPracticalInfo_VisibleTo(t *testing.T) {
+	info := &PracticalInfo{Sections: []Info{
+		{Title: "Le lieu"},
+		{Title: "Hébergement", Audience: []string{"famille"}},
+	}}
+
+	if sections := info.VisibleTo(nil).Sections; len(sections) != 1 || sections[0].Title != "Le lieu" {
+		t.Errorf("VisibleTo(nil) = %v, restricted section should be hidden from anonymous visitors", sections)
+	}
+
+	if sections := info.VisibleTo(&Invitation{Groups: []string{"famille"}}).Sections; len(sections) != 2 {
+		t.Errorf("VisibleTo(family) returned %d sections, want 2", len(sections))
+	}
+}
+
+func TestInvitationSetEmail(t *testing.T) {
+	invitation, _ := NewInvitation("Famille Dupont", nil)
+
+	if err := invitation.SetEmail(" dupont@example.com "); err != nil || invitation.Email != "dupont@example.com" {
+		t.Errorf("SetEmail() = %v, Email = %q", err, invitation.Email)
+	}
+	if err := invitation.SetEmail("dupont"); err != ErrInvalidEmail || invitation.Email != "dupont@example.com" {
+		t.Errorf("SetEmail(invalid) = %v, Email = %q, want ErrInvalidEmail and the address kept", err, invitation.Email)
+	}
+	if err := invitation.SetEmail(""); err != nil || invitation.Email != "" {
+		t.Errorf("SetEmail(\"\") = %v, Email = %q, want the address removed", err, invitation.Email)
+	}
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidPhoto         = errors.New("photo invalide")
+	ErrPhotoTooLarge        = errors.New("photo trop volumineuse")
+	ErrUnsupportedPhotoType = errors.New("format de photo non supporté")
+)
+
+// MaxPhotoSize est la taille maximale d'une photo envoyée (10 Mo)
+const MaxPhotoSize = 10 << 20
+
+// photoContentTypes liste les formats acceptés, détectés à partir du contenu
+var photoContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+}
+
+// PhotoStatus représente l'état de modération d'une photo
+type PhotoStatus string
+
+const (
+	PhotoPending  PhotoStatus = "pending"  // En attente de modération
+	PhotoApproved PhotoStatus = "approved" // Visible dans la galerie
+)
+
+// Photo décrit une photo partagée par un invité. Les fichiers image
+// (photo et miniature) sont stockés à part, sous l'ID de la photo.
+type Photo struct {
+	ID           string      `json:"id"`
+	InvitationID string      `json:"invitation_id"` // Foyer auteur
+	UploadedBy   string      `json:"uploaded_by"`
+	Caption      string      `json:"caption,omitempty"`
+	Width        int         `json:"width"`
+	Height       int         `json:"height"`
+	Status       PhotoStatus `json:"status"`
+	UploadedAt   time.Time   `json:"uploaded_at"`
+	ApprovedAt   time.Time   `json:"approved_at,omitempty"`
+}
+
+// CheckPhotoUpload vérifie la taille et le format (détecté d'après le
+// contenu, pas d'après le nom du fichier) d'une photo envoyée
+func CheckPhotoUpload(contentType string, size int) error {
+	if size == 0 {
+		return ErrInvalidPhoto
+	}
+	if size > MaxPhotoSize {
+		return ErrPhotoTooLarge
+	}
+	if !photoContentTypes[contentType] {
+		return ErrUnsupportedPhotoType
+	}
+	return nil
+}
+
+// NewPhoto crée une photo en attente de modération
+func NewPhoto(uploadedBy, caption string) (*Photo, error) {
+	uploadedBy = strings.TrimSpace(uploadedBy)
+	if len(uploadedBy) == 0 || len(uploadedBy) > 100 {
+		return nil, ErrInvalidName
+	}
+
+	caption = strings.TrimSpace(caption)
+	if len(caption) > 200 {
+		return nil, ErrMessageTooLong
+	}
+	if linkPattern.MatchString(caption) {
+		return nil, ErrLinkNotAllowed
+	}
+	if containsForbiddenWord(caption) {
+		return nil, ErrForbiddenContent
+	}
+
+	return &Photo{
+		UploadedBy: uploadedBy,
+		Caption:    caption,
+		Status:     PhotoPending,
+		UploadedAt: time.Now(),
+	}, nil
+}
+
+// IsApproved indique si la photo est visible dans la galerie
+func (p *Photo) IsApproved() bool {
+	return p.Status == PhotoApproved
+}
+
+// Approve publie la photo dans la galerie
+func (p *Photo) Approve(now time.Time) {
+	p.Status = PhotoApproved
+	p.ApprovedAt = now
+}
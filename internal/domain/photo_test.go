@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckPhotoUpload(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		size        int
+		wantErr     error
+	}{
+		{"jpeg", "image/jpeg", 3 << 20, nil},
+		{"png", "image/png", 1024, nil},
+		{"empty file", "image/jpeg", 0, ErrInvalidPhoto},
+		{"too large", "image/jpeg", MaxPhotoSize + 1, ErrPhotoTooLarge},
+		{"gif", "image/gif", 1024, ErrUnsupportedPhotoType},
+		{"html disguised as image", "text/html; charset=utf-8", 1024, ErrUnsupportedPhotoType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckPhotoUpload(tt.contentType, tt.size); err != tt.wantErr {
+				t.Errorf("CheckPhotoUpload() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewPhoto(t *testing.T) {
+	tests := []struct {
+		name       string
+		uploadedBy string
+		caption    string
+		wantErr    error
+	}{
+		{"valid", "Jean", "La première danse", nil},
+		{"without caption", "Jean", "", nil},
+		{"missing name", " ", "", ErrInvalidName},
+		{"link in caption", "Jean", "Album complet sur exemple.com", ErrLinkNotAllowed},
+		{"profanity in caption", "Jean", "merde alors", ErrForbiddenContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			photo, err := NewPhoto(tt.uploadedBy, tt.caption)
+			if err != tt.wantErr {
+				t.Errorf("NewPhoto() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && photo.IsApproved() {
+				t.Error("NewPhoto() should be pending moderation")
+			}
+		})
+	}
+}
+
+func TestPhotoApprove(t *testing.T) {
+	photo, _ := NewPhoto("Jean", "")
+	now := time.Now()
+	photo.Approve(now)
+
+	if !photo.IsApproved() || !photo.ApprovedAt.Equal(now) {
+		t.Errorf("Approve() status = %s, approved at %v", photo.Status, photo.ApprovedAt)
+	}
+}
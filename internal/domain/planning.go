@@ -4,62 +4,181 @@ import "time"
 
 // PlanningEvent représente un événement du planning
 type PlanningEvent struct {
+	ID           string // Identifiant stable (liens d'export)
 	Title        string
 	Description  string
 	StartTime    time.Time
 	EndTime      time.Time
-	Location     string
-	Address      string
-	HideTime     bool // Masquer l'heure (par défaut: affiché)
-	HideLocation bool // Masquer la localisation (par défaut: affiché)
+	Venue        *Venue   // Lieu de l'événement (nil: non précisé)
+	HideTime     bool     // Masquer l'heure (par défaut: affiché)
+	HideLocation bool     // Masquer la localisation (par défaut: affiché)
+	Audience     []string // Groupes invités (vide: tous les invités)
+	// Reminders liste les rappels avant le début de l'événement.
+	// nil: rappels par défaut du calendrier ; liste vide: aucun rappel.
+	Reminders []time.Duration
+	// Translations traduit le titre et la description (rédigés en
+	// français) par code de langue ("de", "en")
+	Translations map[string]EventText
+}
+
+// EventText est la traduction des textes d'un événement
+type EventText struct {
+	Title       string
+	Description string
+}
+
+// In retourne l'événement avec ses textes et son lieu dans la langue
+// donnée ; un texte non traduit reste en français
+func (e PlanningEvent) In(lang string) PlanningEvent {
+	if text, ok := e.Translations[lang]; ok {
+		if text.Title != "" {
+			e.Title = text.Title
+		}
+		if text.Description != "" {
+			e.Description = text.Description
+		}
+	}
+	if e.Venue != nil {
+		e.Venue = e.Venue.In(lang)
+	}
+	return e
+}
+
+// IsVisibleTo indique si l'événement concerne l'invitation donnée.
+// Un événement sans audience est public ; sinon l'invitation doit
+// appartenir à l'un des groupes.
+func (e PlanningEvent) IsVisibleTo(invitation *Invitation) bool {
+	return isVisibleTo(e.Audience, invitation)
 }
 
 // Planning représente le planning complet de la journée
 type Planning struct {
-	WeddingDate time.Time
-	Events      []PlanningEvent
+	WeddingDate  time.Time
+	RSVPDeadline time.Time
+	UpdatedAt    time.Time // Dernière modification (Last-Modified et DTSTAMP des calendriers)
+	Events       []PlanningEvent
+}
+
+// FindEvent retourne l'événement correspondant à l'identifiant
+func (p *Planning) FindEvent(id string) (PlanningEvent, bool) {
+	for _, event := range p.Events {
+		if event.ID == id {
+			return event, true
+		}
+	}
+	return PlanningEvent{}, false
+}
+
+// AccommodationNights retourne les nuits pour lesquelles un hébergement
+// peut être demandé : la veille, la nuit du mariage et le lendemain
+func (p *Planning) AccommodationNights() []time.Time {
+	return []time.Time{
+		p.WeddingDate.AddDate(0, 0, -1),
+		p.WeddingDate,
+		p.WeddingDate.AddDate(0, 0, 1),
+	}
+}
+
+// In retourne une copie du planning dont les événements sont traduits dans
+// la langue donnée
+func (p *Planning) In(lang string) *Planning {
+	events := make([]PlanningEvent, len(p.Events))
+	for i, event := range p.Events {
+		events[i] = event.In(lang)
+	}
+	return &Planning{
+		WeddingDate:  p.WeddingDate,
+		RSVPDeadline: p.RSVPDeadline,
+		UpdatedAt:    p.UpdatedAt,
+		Events:       events,
+	}
+}
+
+// VisibleTo retourne une copie du planning limitée aux événements de l'invitation
+func (p *Planning) VisibleTo(invitation *Invitation) *Planning {
+	events := make([]PlanningEvent, 0, len(p.Events))
+	for _, event := range p.Events {
+		if event.IsVisibleTo(invitation) {
+			events = append(events, event)
+		}
+	}
+	return &Planning{
+		WeddingDate:  p.WeddingDate,
+		RSVPDeadline: p.RSVPDeadline,
+		UpdatedAt:    p.UpdatedAt,
+		Events:       events,
+	}
 }
 
 // GetDefaultPlanning retourne le planning par défaut
 func GetDefaultPlanning() *Planning {
 	weddingDate := time.Date(2026, 7, 11, 0, 0, 0, 0, time.UTC)
+	venues := GetDefaultVenues()
 
 	return &Planning{
-		WeddingDate: weddingDate,
+		WeddingDate:  weddingDate,
+		RSVPDeadline: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		// À avancer à chaque modification du planning : les calendriers
+		// abonnés ne se mettent à jour que si cette date change
+		UpdatedAt: time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC),
 		Events: []PlanningEvent{
 			{
-				Title:       "Cérémonie civile",
-				StartTime:   time.Date(2026, 7, 11, 14, 00, 0, 0, time.UTC),
-				EndTime:     time.Date(2026, 7, 11, 15, 00, 0, 0, time.UTC),
-				Location:    "Mairie",
-				Address:     "13 Rue de la Mairie, 77930 Cély",
-				Description: "Se garer dans le parking de la mairie",
+				ID:        "ceremonie-civile",
+				Title:     "Cérémonie civile",
+				StartTime: time.Date(2026, 7, 11, 14, 00, 0, 0, time.UTC),
+				EndTime:   time.Date(2026, 7, 11, 15, 00, 0, 0, time.UTC),
+				Venue:     findVenue(venues, "mairie"),
+				// La veille, puis à temps pour la route jusqu'à Cély
+				Reminders: []time.Duration{24 * time.Hour, 90 * time.Minute},
+				Translations: map[string]EventText{
+					"de": {Title: "Standesamtliche Trauung"},
+					"en": {Title: "Civil ceremony"},
+				},
 			},
 			{
+				ID:        "ceremonie-laique",
 				Title:     "Cérémonie laïque",
 				StartTime: time.Date(2026, 7, 11, 15, 30, 0, 0, time.UTC),
 				EndTime:   time.Date(2026, 7, 11, 16, 30, 0, 0, time.UTC),
-				Location:  "Chez nous",
-				Address:   "8 rue du bois beaudoin, 77930 Cély",
+				Venue:     findVenue(venues, "chez-nous"),
+				// Enchaîne avec la mairie : un seul rappel, juste avant
+				Reminders: []time.Duration{30 * time.Minute},
+				Translations: map[string]EventText{
+					"de": {Title: "Freie Trauung"},
+					"en": {Title: "Wedding ceremony"},
+				},
 			},
 			{
+				ID:           "seance-photo",
 				Title:        "Séance photo",
 				Description:  "Photos des mariés et des invités",
 				HideTime:     true,
 				HideLocation: true,
+				Translations: map[string]EventText{
+					"de": {Title: "Fotoshooting", Description: "Fotos vom Brautpaar und den Gästen"},
+					"en": {Title: "Photo session", Description: "Photos of the couple and the guests"},
+				},
 			},
 			{
-				Title:       "Vin d'honneur",
-				StartTime:   time.Date(2026, 7, 11, 18, 00, 0, 0, time.UTC),
-				EndTime:     time.Date(2026, 7, 11, 20, 00, 0, 0, time.UTC),
-				Location:    "La Bergerie",
-				Address:     "Rue de la Bascule, 77190 Villiers-en-Bière",
-				Description: "Un grand parking est disponible sur place.",
+				ID:        "vin-honneur",
+				Title:     "Vin d'honneur",
+				StartTime: time.Date(2026, 7, 11, 18, 00, 0, 0, time.UTC),
+				EndTime:   time.Date(2026, 7, 11, 20, 00, 0, 0, time.UTC),
+				Venue:     findVenue(venues, "bergerie"),
+				Translations: map[string]EventText{
+					"de": {Title: "Sektempfang"},
+					"en": {Title: "Drinks reception"},
+				},
 			},
 			{
+				ID:           "diner",
 				Title:        "Diner",
 				HideTime:     true,
 				HideLocation: true,
+				Translations: map[string]EventText{
+					"de": {Title: "Abendessen"},
+					"en": {Title: "Dinner"},
+				},
 			},
 		},
 	}
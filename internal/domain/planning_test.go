@@ -0,0 +1,28 @@
+package domain
+
+import "testing"
+
+func TestPlanningIn(t *testing.T) {
+	planning := GetDefaultPlanning()
+
+	german := planning.In("de")
+	if got := german.Events[0].Title; got != "Standesamtliche Trauung" {
+		t.Errorf("Title in de = %q, want the German title", got)
+	}
+	if got := german.Events[0].Venue.Name; got != "Rathaus" {
+		t.Errorf("Venue.Name in de = %q, want the German name", got)
+	}
+	if got := german.Events[3].Venue.Name; got != "La Bergerie" {
+		t.Errorf("untranslated Venue.Name = %q, want the French name", got)
+	}
+
+	// Une langue sans traduction garde les textes français
+	if got := planning.In("it").Events[0].Title; got != "Cérémonie civile" {
+		t.Errorf("Title in it = %q, want the French title", got)
+	}
+
+	// Le planning d'origine n'est pas modifié
+	if planning.Events[0].Title != "Cérémonie civile" || planning.Events[0].Venue.Name != "Mairie" {
+		t.Error("In() should not modify the original planning")
+	}
+}
@@ -2,7 +2,8 @@ package ports
 
 import "wedding-web/internal/domain"
 
-// CalendarGenerator génère des fichiers calendar (.ics)
+// CalendarGenerator génère des fichiers calendar (.ics) dans la langue donnée :
+// un code de langue disponible, voir i18n.Locales()
 type CalendarGenerator interface {
-	GenerateICS(planning *domain.Planning) ([]byte, error)
+	GenerateICS(planning *domain.Planning, lang string) ([]byte, error)
 }
@@ -0,0 +1,13 @@
+package ports
+
+import "wedding-web/internal/domain"
+
+// PracticalInfoSource définit le port de lecture des infos pratiques rédigées
+type PracticalInfoSource interface {
+	LoadPracticalInfo(lang string) (*domain.PracticalInfo, error)
+}
+
+// AccommodationSource définit le port de lecture de l'annuaire des hébergements rédigé
+type AccommodationSource interface {
+	LoadAccommodations(lang string) ([]domain.Accommodation, error)
+}
@@ -0,0 +1,8 @@
+package ports
+
+import "wedding-web/internal/domain"
+
+// Mailer définit le port d'envoi des emails
+type Mailer interface {
+	Send(email domain.Email) error
+}
@@ -9,3 +9,92 @@ type RSVPStorage interface {
 	FindByID(id string) (*domain.RSVP, error)
 	Delete(id string) error
 }
+
+// InvitationStorage définit le port pour la persistance des invitations
+type InvitationStorage interface {
+	Save(invitation *domain.Invitation) error
+	FindAll() ([]*domain.Invitation, error)
+	FindByID(id string) (*domain.Invitation, error)
+	FindByCode(code string) (*domain.Invitation, error)
+	Delete(id string) error
+}
+
+// CarpoolStorage définit le port pour la persistance des annonces de covoiturage
+type CarpoolStorage interface {
+	Save(entry *domain.CarpoolEntry) error
+	FindAll() ([]*domain.CarpoolEntry, error)
+	FindByID(id string) (*domain.CarpoolEntry, error)
+	Delete(id string) error
+}
+
+// SongStorage définit le port pour la persistance des suggestions musicales
+type SongStorage interface {
+	Save(song *domain.SongSuggestion) error
+	FindAll() ([]*domain.SongSuggestion, error)
+	FindByID(id string) (*domain.SongSuggestion, error)
+	Delete(id string) error
+}
+
+// GuestbookStorage définit le port pour la persistance du livre d'or
+type GuestbookStorage interface {
+	Save(entry *domain.GuestbookEntry) error
+	FindAll() ([]*domain.GuestbookEntry, error)
+	FindByID(id string) (*domain.GuestbookEntry, error)
+	Delete(id string) error
+}
+
+// RegistryStorage définit le port pour la persistance de la liste de mariage
+type RegistryStorage interface {
+	Save(item *domain.RegistryItem) error
+	FindAll() ([]*domain.RegistryItem, error)
+	FindByID(id string) (*domain.RegistryItem, error)
+	Delete(id string) error
+}
+
+// PhotoStorage définit le port pour la persistance des photos des invités
+type PhotoStorage interface {
+	Save(photo *domain.Photo) error
+	FindAll() ([]*domain.Photo, error)
+	FindByID(id string) (*domain.Photo, error)
+	Delete(id string) error
+}
+
+// PhotoFileStore définit le port pour les fichiers image des photos
+// (photo réencodée et miniature), rangés sous l'ID de la photo
+type PhotoFileStore interface {
+	SaveImages(id string, photo, thumbnail []byte) error
+	ReadImage(id string, thumbnail bool) ([]byte, error)
+	DeleteImages(id string) error
+}
+
+// SeatingStorage définit le port pour la persistance du plan de table
+type SeatingStorage interface {
+	Save(table *domain.Table) error
+	FindAll() ([]*domain.Table, error)
+	FindByID(id string) (*domain.Table, error)
+	Delete(id string) error
+}
+
+// ThankYouStorage définit le port pour la persistance du suivi des remerciements
+type ThankYouStorage interface {
+	Save(thanks *domain.ThankYou) error
+	FindAll() ([]*domain.ThankYou, error)
+	FindByID(id string) (*domain.ThankYou, error)
+	Delete(id string) error
+}
+
+// EmailQueueStorage définit le port pour la persistance des emails en attente d'envoi
+type EmailQueueStorage interface {
+	Save(email *domain.QueuedEmail) error
+	FindAll() ([]*domain.QueuedEmail, error)
+	FindByID(id string) (*domain.QueuedEmail, error)
+	Delete(id string) error
+}
+
+// ReminderCampaignStorage définit le port pour la persistance des campagnes de relance
+type ReminderCampaignStorage interface {
+	Save(campaign *domain.ReminderCampaign) error
+	FindAll() ([]*domain.ReminderCampaign, error)
+	FindByID(id string) (*domain.ReminderCampaign, error)
+	Delete(id string) error
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidGift         = errors.New("cadeau invalide")
+	ErrInvalidGiftImage    = errors.New("image du cadeau invalide")
+	ErrGiftAlreadyReserved = errors.New("cadeau déjà réservé")
+	ErrInvalidContribution = errors.New("participation invalide")
+	ErrReservationNotFound = errors.New("réservation introuvable")
+)
+
+// GiftReservation est l'engagement d'un invité à offrir un cadeau ou à
+// participer à une cagnotte
+type GiftReservation struct {
+	ID           string    `json:"id"`
+	InvitationID string    `json:"invitation_id,omitempty"` // Foyer auteur (vide: invité non identifié)
+	Name         string    `json:"name"`
+	Anonymous    bool      `json:"anonymous"`        // Nom masqué aux autres invités (pas aux mariés)
+	Amount       int       `json:"amount,omitempty"` // Montant en euros (prix du cadeau ou participation à la cagnotte)
+	ReservedAt   time.Time `json:"reserved_at"`
+}
+
+// RegistryItem est un cadeau de la liste de mariage ou une cagnotte
+// (voyage de noces...) à laquelle plusieurs invités participent
+type RegistryItem struct {
+	ID           string            `json:"id"`
+	Title        string            `json:"title"`
+	Description  string            `json:"description,omitempty"`
+	ImageURL     string            `json:"image_url,omitempty"`
+	Price        int               `json:"price"` // Prix du cadeau, ou objectif de la cagnotte, en euros
+	Fund         bool              `json:"fund"`  // Cagnotte : plusieurs participations possibles
+	Reservations []GiftReservation `json:"reservations,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+// NewRegistryItem crée un cadeau ou une cagnotte avec validation.
+// L'image est une URL web absolue ou un fichier du site (/static/...).
+func NewRegistryItem(title, description, imageURL string, price int, fund bool) (*RegistryItem, error) {
+	title = strings.TrimSpace(title)
+	description = strings.TrimSpace(description)
+	if len(title) == 0 || len(title) > 200 || len(description) > 1000 {
+		return nil, ErrInvalidGift
+	}
+	if price < 0 || price > 100000 || (price == 0 && !fund) {
+		return nil, ErrInvalidGift
+	}
+
+	imageURL = strings.TrimSpace(imageURL)
+	if imageURL != "" && !isImageURL(imageURL) {
+		return nil, ErrInvalidGiftImage
+	}
+
+	return &RegistryItem{
+		Title:       title,
+		Description: description,
+		ImageURL:    imageURL,
+		Price:       price,
+		Fund:        fund,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// NewGiftReservation crée une réservation avec validation. Le montant n'est
+// utilisé que pour les cagnottes.
+func NewGiftReservation(name string, anonymous bool, amount int) (*GiftReservation, error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 || len(name) > 100 {
+		return nil, ErrInvalidName
+	}
+	if amount < 0 || amount > 100000 {
+		return nil, ErrInvalidContribution
+	}
+
+	return &GiftReservation{
+		Name:       name,
+		Anonymous:  anonymous,
+		Amount:     amount,
+		ReservedAt: time.Now(),
+	}, nil
+}
+
+// IsReserved indique si le cadeau n'est plus disponible (jamais pour une cagnotte)
+func (i *RegistryItem) IsReserved() bool {
+	return !i.Fund && len(i.Reservations) > 0
+}
+
+// Reserve ajoute une réservation : un cadeau ne peut être réservé qu'une fois,
+// une participation à une cagnotte doit indiquer un montant
+func (i *RegistryItem) Reserve(reservation GiftReservation) error {
+	if i.Fund {
+		if reservation.Amount <= 0 {
+			return ErrInvalidContribution
+		}
+	} else {
+		if i.IsReserved() {
+			return ErrGiftAlreadyReserved
+		}
+		reservation.Amount = i.Price
+	}
+
+	i.Reservations = append(i.Reservations, reservation)
+	return nil
+}
+
+// Release annule une réservation par son ID
+func (i *RegistryItem) Release(reservationID string) error {
+	for j, reservation := range i.Reservations {
+		if reservation.ID == reservationID {
+			i.Reservations = append(i.Reservations[:j], i.Reservations[j+1:]...)
+			return nil
+		}
+	}
+	return ErrReservationNotFound
+}
+
+// Collected retourne le total promis, en euros
+func (i *RegistryItem) Collected() int {
+	total := 0
+	for _, reservation := range i.Reservations {
+		total += reservation.Amount
+	}
+	return total
+}
+
+// Progress retourne l'avancement de la cagnotte en pourcentage (plafonné à 100)
+func (i *RegistryItem) Progress() int {
+	if i.Price == 0 {
+		return 0
+	}
+	return min(100, i.Collected()*100/i.Price)
+}
+
+// isImageURL vérifie qu'une image est une URL web absolue ou un chemin du site
+func isImageURL(link string) bool {
+	if strings.HasPrefix(link, "/static/") && !strings.Contains(link, "..") {
+		return true
+	}
+	return isWebLink(link)
+}
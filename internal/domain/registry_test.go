@@ -0,0 +1,73 @@
+package domain
+
+import "testing"
+
+func TestNewRegistryItem(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		imageURL string
+		price    int
+		fund     bool
+		wantErr  error
+	}{
+		{"gift", "Service à raclette", "https://example.com/raclette.jpg", 80, false, nil},
+		{"site image", "Service à raclette", "/static/images/raclette.jpg", 80, false, nil},
+		{"fund without target", "Voyage de noces", "", 0, true, nil},
+		{"gift without price", "Service à raclette", "", 0, false, ErrInvalidGift},
+		{"negative price", "Service à raclette", "", -5, false, ErrInvalidGift},
+		{"missing title", " ", "", 80, false, ErrInvalidGift},
+		{"unsafe image", "Service à raclette", "javascript:alert(1)", 80, false, ErrInvalidGiftImage},
+		{"traversal image", "Service à raclette", "/static/../conf/prod.yaml", 80, false, ErrInvalidGiftImage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRegistryItem(tt.title, "", tt.imageURL, tt.price, tt.fund)
+			if err != tt.wantErr {
+				t.Errorf("NewRegistryItem() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRegistryItem_Reserve(t *testing.T) {
+	gift, _ := NewRegistryItem("Service à raclette", "", "", 80, false)
+	first, _ := NewGiftReservation("Jean", false, 0)
+	first.ID = "r1"
+	if err := gift.Reserve(*first); err != nil {
+		t.Fatalf("Reserve() error = %v", err)
+	}
+	if !gift.IsReserved() || gift.Collected() != 80 {
+		t.Errorf("Reserved gift = %+v", gift)
+	}
+
+	// Un cadeau n'est offert qu'une fois
+	second, _ := NewGiftReservation("Marie", true, 0)
+	if err := gift.Reserve(*second); err != ErrGiftAlreadyReserved {
+		t.Errorf("Reserve() twice error = %v, want %v", err, ErrGiftAlreadyReserved)
+	}
+
+	if err := gift.Release("r1"); err != nil || gift.IsReserved() {
+		t.Errorf("Release() error = %v, reserved = %v", err, gift.IsReserved())
+	}
+	if err := gift.Release("r1"); err != ErrReservationNotFound {
+		t.Errorf("Release() unknown error = %v, want %v", err, ErrReservationNotFound)
+	}
+
+	// Une cagnotte accepte plusieurs participations
+	fund, _ := NewRegistryItem("Voyage de noces", "", "", 2000, true)
+	for _, amount := range []int{500, 1000, 800} {
+		contribution, _ := NewGiftReservation("Jean", false, amount)
+		if err := fund.Reserve(*contribution); err != nil {
+			t.Fatalf("Reserve() fund error = %v", err)
+		}
+	}
+	if fund.IsReserved() || fund.Collected() != 2300 || fund.Progress() != 100 {
+		t.Errorf("Fund = reserved %v, collected %d, progress %d", fund.IsReserved(), fund.Collected(), fund.Progress())
+	}
+	empty, _ := NewGiftReservation("Marie", false, 0)
+	if err := fund.Reserve(*empty); err != ErrInvalidContribution {
+		t.Errorf("Reserve() fund without amount error = %v, want %v", err, ErrInvalidContribution)
+	}
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidReminderCampaign = errors.New("campagne de relance invalide")
+)
+
+// ReminderCampaign est une relance des invitations restées sans réponse,
+// prévue DaysBefore jours avant la date limite de réponse. Les envois sont
+// mémorisés : un foyer n'est relancé qu'une fois par campagne.
+type ReminderCampaign struct {
+	ID         string         `json:"id"` // Ex: "j-14"
+	DaysBefore int            `json:"days_before"`
+	RunAt      time.Time      `json:"run_at,omitempty"` // Dernier passage de la campagne (zéro : jamais lancée)
+	Sent       []ReminderSent `json:"sent,omitempty"`
+}
+
+// ReminderSent est l'envoi d'une relance à un foyer
+type ReminderSent struct {
+	InvitationID string    `json:"invitation_id"`
+	SentAt       time.Time `json:"sent_at"`
+}
+
+// NewReminderCampaign crée la campagne envoyée daysBefore jours avant la
+// date limite de réponse
+func NewReminderCampaign(daysBefore int) (*ReminderCampaign, error) {
+	if daysBefore < 1 || daysBefore > 365 {
+		return nil, ErrInvalidReminderCampaign
+	}
+
+	return &ReminderCampaign{
+		ID:         ReminderCampaignID(daysBefore),
+		DaysBefore: daysBefore,
+	}, nil
+}
+
+// ReminderCampaignID retourne l'identifiant de la campagne envoyée
+// daysBefore jours avant la date limite
+func ReminderCampaignID(daysBefore int) string {
+	return fmt.Sprintf("j-%d", daysBefore)
+}
+
+// ScheduledAt retourne la date d'envoi de la campagne : DaysBefore jours
+// avant la date limite, à l'heure at (depuis minuit)
+func (c *ReminderCampaign) ScheduledAt(deadline time.Time, at time.Duration) time.Time {
+	day := deadline.AddDate(0, 0, -c.DaysBefore)
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local).Add(at)
+}
+
+// HasRun indique si la campagne a déjà été lancée
+func (c *ReminderCampaign) HasRun() bool {
+	return !c.RunAt.IsZero()
+}
+
+// SentTo indique si le foyer a déjà reçu cette relance
+func (c *ReminderCampaign) SentTo(invitationID string) bool {
+	for _, sent := range c.Sent {
+		if sent.InvitationID == invitationID {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkSent enregistre l'envoi de la relance à un foyer
+func (c *ReminderCampaign) MarkSent(invitationID string, at time.Time) {
+	if c.SentTo(invitationID) {
+		return
+	}
+	c.Sent = append(c.Sent, ReminderSent{InvitationID: invitationID, SentAt: at})
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewReminderCampaign(t *testing.T) {
+	campaign, err := NewReminderCampaign(14)
+	if err != nil {
+		t.Fatalf("NewReminderCampaign() error = %v", err)
+	}
+	if campaign.ID != "j-14" || campaign.HasRun() {
+		t.Errorf("NewReminderCampaign() = %+v", campaign)
+	}
+
+	for _, days := range []int{0, -3, 400} {
+		if _, err := NewReminderCampaign(days); err != ErrInvalidReminderCampaign {
+			t.Errorf("NewReminderCampaign(%d) error = %v, want ErrInvalidReminderCampaign", days, err)
+		}
+	}
+}
+
+func TestReminderCampaignScheduledAt(t *testing.T) {
+	campaign, _ := NewReminderCampaign(7)
+	deadline := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	got := campaign.ScheduledAt(deadline, 10*time.Hour+30*time.Minute)
+	if want := time.Date(2026, 2, 22, 10, 30, 0, 0, time.Local); !got.Equal(want) {
+		t.Errorf("ScheduledAt() = %v, want %v", got, want)
+	}
+}
+
+func TestReminderCampaignMarkSent(t *testing.T) {
+	campaign, _ := NewReminderCampaign(7)
+	now := time.Now()
+
+	campaign.MarkSent("inv-1", now)
+	campaign.MarkSent("inv-1", now.Add(time.Hour))
+	if !campaign.SentTo("inv-1") || campaign.SentTo("inv-2") {
+		t.Errorf("SentTo() wrong after MarkSent: %+v", campaign.Sent)
+	}
+	if len(campaign.Sent) != 1 || !campaign.Sent[0].SentAt.Equal(now) {
+		t.Errorf("Sent = %+v, want the first send only", campaign.Sent)
+	}
+}
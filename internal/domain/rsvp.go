@@ -17,6 +17,7 @@ var (
 // RSVP représente une réservation
 type RSVP struct {
 	ID            string    `json:"id"`
+	InvitationID  string    `json:"invitation_id,omitempty"` // Invitation du foyer (vide: réponse sans lien personnel)
 	FirstName     string    `json:"first_name"`
 	LastName      string    `json:"last_name"`
 	WillAttend    bool      `json:"will_attend"`
@@ -25,7 +26,13 @@ type RSVP struct {
 	Allergies     string    `json:"allergies"`
 	Message       string    `json:"message"`
 	SubmittedAt   time.Time `json:"submitted_at"`
-	IPAddress     string    `json:"-"` // Ne pas persister l'IP
+	IPAddress     string    `json:"-"`                    // Ne pas persister l'IP
+	Lang          string    `json:"lang,omitempty"`       // Langue du site lors de la réponse (vide: réponses antérieures)
+	Email         string    `json:"email,omitempty"`      // Adresse de l'invité pour la confirmation (facultative)
+	EditToken     string    `json:"edit_token,omitempty"` // Secret du lien de modification de la réponse
+
+	// Nuits pour lesquelles le foyer cherche un hébergement (format NightDateFormat)
+	AccommodationNights []string `json:"accommodation_nights,omitempty"`
 }
 
 // NewRSVP crée une nouvelle réservation avec validation
@@ -93,3 +100,59 @@ func (r *RSVP) TotalGuests() int {
 func (r *RSVP) FullName() string {
 	return r.FirstName + " " + r.LastName
 }
+
+// SetAccommodationNights enregistre les nuits d'hébergement demandées, qui
+// doivent faire partie des nuits proposées. Les absents n'en ont pas besoin.
+func (r *RSVP) SetAccommodationNights(nights []string, allowed []time.Time) error {
+	r.AccommodationNights = nil
+	if !r.WillAttend {
+		return nil
+	}
+
+	requested := make(map[string]bool)
+	for _, night := range nights {
+		requested[strings.TrimSpace(night)] = true
+	}
+
+	// Conserver l'ordre chronologique des nuits proposées
+	for _, night := range allowed {
+		value := night.Format(NightDateFormat)
+		if requested[value] {
+			r.AccommodationNights = append(r.AccommodationNights, value)
+			delete(requested, value)
+		}
+	}
+	delete(requested, "")
+
+	if len(requested) > 0 {
+		r.AccommodationNights = nil
+		return ErrInvalidNights
+	}
+	return nil
+}
+
+// SetEmail enregistre l'adresse de l'invité (vide: pas de confirmation par email)
+func (r *RSVP) SetEmail(email string) error {
+	email, err := NormalizeEmail(email)
+	if err != nil {
+		return err
+	}
+	r.Email = email
+	return nil
+}
+
+// NeedsAccommodation indique si le foyer cherche un hébergement
+func (r *RSVP) NeedsAccommodation() bool {
+	return len(r.AccommodationNights) > 0
+}
+
+// NeedsAccommodationOn indique si le foyer cherche un hébergement pour la nuit donnée
+func (r *RSVP) NeedsAccommodationOn(night time.Time) bool {
+	value := night.Format(NightDateFormat)
+	for _, n := range r.AccommodationNights {
+		if n == value {
+			return true
+		}
+	}
+	return false
+}
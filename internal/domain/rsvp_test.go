@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -146,6 +147,33 @@ func TestRSVP_FullName(t *testing.T) {
 	}
 }
 
+func TestRSVP_SetEmail(t *testing.T) {
+	tests := []struct {
+		email   string
+		want    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"  anna@example.com ", "anna@example.com", false},
+		{"pas une adresse", "", true},
+		{"Anna <anna@example.com>", "", true},
+		{"anna@example.com\r\nBcc: intrus@example.com", "", true},
+		{strings.Repeat("a", 250) + "@example.com", "", true},
+	}
+
+	for _, tt := range tests {
+		rsvp, _ := NewRSVP("Anna", "Müller", true, 1, 0, "", "")
+		err := rsvp.SetEmail(tt.email)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("SetEmail(%q) error = %v, wantErr %v", tt.email, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && rsvp.Email != tt.want {
+			t.Errorf("SetEmail(%q) Email = %q, want %q", tt.email, rsvp.Email, tt.want)
+		}
+	}
+}
+
 func TestGetDefaultPlanning(t *testing.T) {
 	planning := GetDefaultPlanning()
 
@@ -167,14 +195,24 @@ func TestGetDefaultPlanning(t *testing.T) {
 	}
 }
 
-func TestGetDefaultPracticalInfo(t *testing.T) {
-	info := GetDefaultPracticalInfo()
+func TestPracticalInfo_Validate(t *testing.T) {
+	valid := &PracticalInfo{Sections: []Info{{ID: "lieu", Title: "Le lieu"}, {Title: "FAQ"}}}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	untitled := &PracticalInfo{Sections: []Info{{ID: "lieu", Content: "Texte"}}}
+	if err := untitled.Validate(); err != ErrInvalidPracticalInfo {
+		t.Errorf("Validate() untitled error = %v, want %v", err, ErrInvalidPracticalInfo)
+	}
 
-	if info == nil {
-		t.Fatal("GetDefaultPracticalInfo() returned nil")
+	duplicated := &PracticalInfo{Sections: []Info{{ID: "lieu", Title: "A"}, {ID: "lieu", Title: "B"}}}
+	if err := duplicated.Validate(); err != ErrInvalidPracticalInfo {
+		t.Errorf("Validate() duplicated error = %v, want %v", err, ErrInvalidPracticalInfo)
 	}
 
-	if info.Venue.Title == "" {
-		t.Error("Venue title is empty")
+	reserved := &PracticalInfo{Sections: []Info{{ID: AccommodationsAnchor, Title: "Hébergement"}}}
+	if err := reserved.Validate(); err != ErrInvalidPracticalInfo {
+		t.Errorf("Validate() reserved anchor error = %v, want %v", err, ErrInvalidPracticalInfo)
 	}
 }
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidTable   = errors.New("table invalide")
+	ErrInvalidSeating = errors.New("placement invalide")
+)
+
+// MaxTableCapacity limite le nombre de places d'une table
+const MaxTableCapacity = 50
+
+// SeatAssignment place tout ou partie d'un foyer (réponse RSVP) à une table.
+// Un foyer peut être réparti sur plusieurs tables (table des enfants...).
+type SeatAssignment struct {
+	RSVPID   string `json:"rsvp_id"`
+	Adults   int    `json:"adults"`
+	Children int    `json:"children"`
+}
+
+// Table est une table du plan de table
+type Table struct {
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	Capacity  int              `json:"capacity"`
+	Seats     []SeatAssignment `json:"seats,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// NewTable crée une table avec validation
+func NewTable(name string, capacity int) (*Table, error) {
+	table := &Table{CreatedAt: time.Now()}
+	if err := table.Update(name, capacity); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// Update renomme la table et change sa capacité. Une capacité inférieure
+// à l'occupation est acceptée : elle est signalée par CheckSeating.
+func (t *Table) Update(name string, capacity int) error {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 || len(name) > 100 {
+		return ErrInvalidTable
+	}
+	if capacity < 1 || capacity > MaxTableCapacity {
+		return ErrInvalidTable
+	}
+
+	t.Name = name
+	t.Capacity = capacity
+	return nil
+}
+
+// Occupancy retourne le nombre de personnes placées à la table
+func (t *Table) Occupancy() int {
+	total := 0
+	for _, seat := range t.Seats {
+		total += seat.Adults + seat.Children
+	}
+	return total
+}
+
+// Seated retourne le nombre d'adultes et d'enfants d'un foyer placés à la table
+func (t *Table) Seated(rsvpID string) (adults, children int) {
+	for _, seat := range t.Seats {
+		if seat.RSVPID == rsvpID {
+			return seat.Adults, seat.Children
+		}
+	}
+	return 0, 0
+}
+
+// Assign définit le nombre d'adultes et d'enfants d'un foyer placés à la
+// table (0 et 0 retirent le foyer de la table)
+func (t *Table) Assign(rsvpID string, adults, children int) error {
+	if rsvpID == "" || adults < 0 || children < 0 {
+		return ErrInvalidSeating
+	}
+
+	t.Unassign(rsvpID)
+	if adults+children > 0 {
+		t.Seats = append(t.Seats, SeatAssignment{RSVPID: rsvpID, Adults: adults, Children: children})
+	}
+	return nil
+}
+
+// Unassign retire un foyer de la table
+func (t *Table) Unassign(rsvpID string) {
+	for i, seat := range t.Seats {
+		if seat.RSVPID == rsvpID {
+			t.Seats = append(t.Seats[:i], t.Seats[i+1:]...)
+			return
+		}
+	}
+}
+
+// SeatingWarningKind identifie une contrainte non respectée du plan de table
+type SeatingWarningKind string
+
+const (
+	WarningOverCapacity  SeatingWarningKind = "over_capacity"  // Plus de personnes que de places
+	WarningChildrenAlone SeatingWarningKind = "children_alone" // Enfants placés sans adulte de leur foyer
+	WarningOverAssigned  SeatingWarningKind = "over_assigned"  // Foyer placé au-delà du nombre de personnes annoncé
+	WarningNotAttending  SeatingWarningKind = "not_attending"  // Foyer placé mais absent (ou réponse supprimée)
+)
+
+// SeatingWarning signale une contrainte non respectée, pour une table ou un foyer
+type SeatingWarning struct {
+	Kind      SeatingWarningKind
+	TableName string // Table concernée (vide pour un foyer réparti sur plusieurs tables)
+	RSVPID    string // Foyer concerné (vide pour un dépassement de capacité)
+}
+
+// CheckSeating vérifie le plan de table par rapport aux réponses des foyers
+// présents : capacité des tables, enfants placés sans adulte de leur foyer,
+// foyers placés en trop ou qui ne viennent plus
+func CheckSeating(tables []*Table, attending []*RSVP) []SeatingWarning {
+	parties := make(map[string]*RSVP, len(attending))
+	for _, rsvp := range attending {
+		parties[rsvp.ID] = rsvp
+	}
+
+	var warnings []SeatingWarning
+	seatedAdults := map[string]int{}
+	seatedChildren := map[string]int{}
+	reported := map[string]bool{}
+
+	for _, table := range tables {
+		if table.Occupancy() > table.Capacity {
+			warnings = append(warnings, SeatingWarning{Kind: WarningOverCapacity, TableName: table.Name})
+		}
+
+		for _, seat := range table.Seats {
+			if _, ok := parties[seat.RSVPID]; !ok {
+				if !reported[seat.RSVPID] {
+					warnings = append(warnings, SeatingWarning{Kind: WarningNotAttending, TableName: table.Name, RSVPID: seat.RSVPID})
+					reported[seat.RSVPID] = true
+				}
+				continue
+			}
+
+			if seat.Children > 0 && seat.Adults == 0 {
+				warnings = append(warnings, SeatingWarning{Kind: WarningChildrenAlone, TableName: table.Name, RSVPID: seat.RSVPID})
+			}
+			seatedAdults[seat.RSVPID] += seat.Adults
+			seatedChildren[seat.RSVPID] += seat.Children
+		}
+	}
+
+	for _, rsvp := range attending {
+		if seatedAdults[rsvp.ID] > rsvp.AdultsCount || seatedChildren[rsvp.ID] > rsvp.ChildrenCount {
+			warnings = append(warnings, SeatingWarning{Kind: WarningOverAssigned, RSVPID: rsvp.ID})
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,89 @@
+package domain
+
+import "testing"
+
+func TestNewTable(t *testing.T) {
+	tests := []struct {
+		name      string
+		tableName string
+		capacity  int
+		wantErr   error
+	}{
+		{"valid", "Table des témoins", 10, nil},
+		{"missing name", " ", 10, ErrInvalidTable},
+		{"no seats", "Table 1", 0, ErrInvalidTable},
+		{"too many seats", "Table 1", MaxTableCapacity + 1, ErrInvalidTable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTable(tt.tableName, tt.capacity); err != tt.wantErr {
+				t.Errorf("NewTable() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTableAssign(t *testing.T) {
+	table, _ := NewTable("Table 1", 8)
+
+	table.Assign("dupont", 2, 1)
+	table.Assign("martin", 2, 0)
+	table.Assign("dupont", 2, 2) // Remplace le placement précédent
+	if table.Occupancy() != 6 || len(table.Seats) != 2 {
+		t.Errorf("Occupancy() = %d with %d seats, want 6 with 2", table.Occupancy(), len(table.Seats))
+	}
+	if adults, children := table.Seated("dupont"); adults != 2 || children != 2 {
+		t.Errorf("Seated(dupont) = %d, %d, want 2, 2", adults, children)
+	}
+
+	table.Assign("martin", 0, 0)
+	if table.Occupancy() != 4 {
+		t.Errorf("Occupancy() after removal = %d, want 4", table.Occupancy())
+	}
+
+	if err := table.Assign("dupont", -1, 0); err != ErrInvalidSeating {
+		t.Errorf("Assign() negative error = %v, want %v", err, ErrInvalidSeating)
+	}
+}
+
+func TestCheckSeating(t *testing.T) {
+	dupont := &RSVP{ID: "dupont", WillAttend: true, AdultsCount: 2, ChildrenCount: 2}
+	martin := &RSVP{ID: "martin", WillAttend: true, AdultsCount: 2}
+
+	honneur, _ := NewTable("Honneur", 4)
+	honneur.Assign("dupont", 2, 0)
+	honneur.Assign("martin", 2, 0)
+	honneur.Assign("absent", 1, 0)
+
+	enfants, _ := NewTable("Enfants", 6)
+	enfants.Assign("dupont", 0, 2)
+	enfants.Assign("martin", 0, 1)
+
+	warnings := CheckSeating([]*Table{honneur, enfants}, []*RSVP{dupont, martin})
+
+	want := []SeatingWarning{
+		{Kind: WarningOverCapacity, TableName: "Honneur"},
+		{Kind: WarningNotAttending, TableName: "Honneur", RSVPID: "absent"},
+		{Kind: WarningChildrenAlone, TableName: "Enfants", RSVPID: "dupont"},
+		{Kind: WarningChildrenAlone, TableName: "Enfants", RSVPID: "martin"},
+		{Kind: WarningOverAssigned, RSVPID: "martin"},
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("CheckSeating() = %+v, want %+v", warnings, want)
+	}
+	for i := range want {
+		if warnings[i] != want[i] {
+			t.Errorf("CheckSeating()[%d] = %+v, want %+v", i, warnings[i], want[i])
+		}
+	}
+
+	// Un plan respectant les contraintes ne produit aucun avertissement
+	honneur.Assign("absent", 0, 0)
+	enfants.Assign("martin", 0, 0)
+	enfants.Assign("dupont", 1, 2)
+	honneur.Assign("dupont", 1, 0)
+	if warnings := CheckSeating([]*Table{honneur, enfants}, []*RSVP{dupont, martin}); len(warnings) != 0 {
+		t.Errorf("CheckSeating() = %+v, want no warning", warnings)
+	}
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrInvalidSong     = errors.New("suggestion musicale invalide")
+	ErrInvalidSongLink = errors.New("lien musical invalide")
+)
+
+// SongSuggestion est un morceau proposé par un invité pour la soirée
+type SongSuggestion struct {
+	ID           string    `json:"id"`
+	InvitationID string    `json:"invitation_id,omitempty"` // Foyer auteur (vide: invité non identifié)
+	RSVPID       string    `json:"rsvp_id,omitempty"`       // Réponse RSVP associée (proposé avec le RSVP)
+	SuggestedBy  string    `json:"suggested_by"`
+	Title        string    `json:"title"`
+	Artist       string    `json:"artist"`
+	Link         string    `json:"link,omitempty"` // Lien d'écoute (YouTube, Spotify...)
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// NewSongSuggestion crée une suggestion musicale avec validation
+func NewSongSuggestion(suggestedBy, title, artist, link string) (*SongSuggestion, error) {
+	suggestedBy = strings.TrimSpace(suggestedBy)
+	if len(suggestedBy) > 100 {
+		return nil, ErrInvalidName
+	}
+
+	title = strings.TrimSpace(title)
+	artist = strings.TrimSpace(artist)
+	if len(title) == 0 || len(title) > 200 || len(artist) == 0 || len(artist) > 200 {
+		return nil, ErrInvalidSong
+	}
+
+	link = strings.TrimSpace(link)
+	if link != "" && !isWebLink(link) {
+		return nil, ErrInvalidSongLink
+	}
+
+	return &SongSuggestion{
+		SuggestedBy: suggestedBy,
+		Title:       title,
+		Artist:      artist,
+		Link:        link,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// Key retourne la clé de dédoublonnage du morceau : titre et artiste sans
+// casse, accents ni ponctuation
+func (s *SongSuggestion) Key() string {
+	return normalizeText(s.Artist) + "|" + normalizeText(s.Title)
+}
+
+// normalizeText ramène un texte à ses lettres et chiffres en minuscules,
+// séparés par une espace. La décomposition NFD (golang.org/x/text, seule
+// implémentation disponible hors bibliothèque standard) sépare les accents
+// de leur lettre, quelle que soit la langue : "Beyoncé" et "Beyonce" se confondent.
+func normalizeText(text string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range norm.NFD.String(text) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Accent détaché par la décomposition
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			space = false
+		default:
+			space = true
+		}
+	}
+	return b.String()
+}
+
+// isWebLink vérifie qu'un lien est une URL web absolue (http ou https)
+func isWebLink(link string) bool {
+	if len(link) > 500 {
+		return false
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "https" || u.Scheme == "http") && u.Host != ""
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "testing"
+
+func TestNewSongSuggestion(t *testing.T) {
+	tests := []struct {
+		name    string
+		title   string
+		artist  string
+		link    string
+		wantErr error
+	}{
+		{"valid", "Dancing Queen", "ABBA", "", nil},
+		{"valid with link", "Alors on danse", "Stromae", "https://www.youtube.com/watch?v=VHoT4N43jK8", nil},
+		{"missing artist", "Dancing Queen", " ", "", ErrInvalidSong},
+		{"missing title", "", "ABBA", "", ErrInvalidSong},
+		{"unsafe link", "Dancing Queen", "ABBA", "javascript:alert(1)", ErrInvalidSongLink},
+		{"relative link", "Dancing Queen", "ABBA", "/songs", ErrInvalidSongLink},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSongSuggestion("Jean", tt.title, tt.artist, tt.link)
+			if err != tt.wantErr {
+				t.Errorf("NewSongSuggestion() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSongSuggestion_Key(t *testing.T) {
+	a, _ := NewSongSuggestion("", "Céline  Dion", "Pour que tu m'aimes encore", "")
+	b, _ := NewSongSuggestion("", "celine dion", "Pour que tu m’aimes encore!", "")
+	c, _ := NewSongSuggestion("", "Céline Dion", "S'il suffisait d'aimer", "")
+
+	if a.Key() != b.Key() {
+		t.Errorf("Key() = %q and %q, want the same key", a.Key(), b.Key())
+	}
+	if a.Key() == c.Key() {
+		t.Errorf("Key() = %q for two different songs", a.Key())
+	}
+}
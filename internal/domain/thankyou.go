@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidThankYou = errors.New("remerciement invalide")
+	ErrInvalidAddress  = errors.New("adresse postale invalide")
+)
+
+// PostalAddress est l'adresse postale d'un foyer, pour l'envoi des cartes
+type PostalAddress struct {
+	Street     string `json:"street,omitempty"` // Rue, complément (plusieurs lignes possibles)
+	PostalCode string `json:"postal_code,omitempty"`
+	City       string `json:"city,omitempty"`
+	Country    string `json:"country,omitempty"`
+}
+
+// IsEmpty indique si aucune partie de l'adresse n'est renseignée
+func (a PostalAddress) IsEmpty() bool {
+	return a.Street == "" && a.PostalCode == "" && a.City == "" && a.Country == ""
+}
+
+// ThankYou est le suivi des remerciements d'un foyer après le mariage :
+// cadeau reçu, note personnelle et date d'envoi de la carte. Le foyer est
+// une invitation, ou une réponse faite sans lien personnel.
+type ThankYou struct {
+	ID           string        `json:"id"`
+	InvitationID string        `json:"invitation_id,omitempty"`
+	RSVPID       string        `json:"rsvp_id,omitempty"` // Réponse sans invitation
+	Gift         string        `json:"gift,omitempty"`    // Cadeau reçu
+	Note         string        `json:"note,omitempty"`    // Note pour la carte (anecdote, prénoms des enfants...)
+	Address      PostalAddress `json:"address"`
+	SentAt       time.Time     `json:"sent_at,omitempty"` // Date d'envoi de la carte (zéro : pas encore remercié)
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+// NewThankYou crée le suivi des remerciements d'un foyer, identifié par son
+// invitation ou, à défaut, par sa réponse
+func NewThankYou(invitationID, rsvpID string) (*ThankYou, error) {
+	if (invitationID == "") == (rsvpID == "") {
+		return nil, ErrInvalidThankYou
+	}
+
+	return &ThankYou{
+		InvitationID: invitationID,
+		RSVPID:       rsvpID,
+		UpdatedAt:    time.Now(),
+	}, nil
+}
+
+// Update enregistre le cadeau reçu, la note et l'adresse postale du foyer
+func (t *ThankYou) Update(gift, note string, address PostalAddress) error {
+	gift = strings.TrimSpace(gift)
+	note = strings.TrimSpace(note)
+	if len(gift) > 500 || len(note) > 1000 {
+		return ErrInvalidThankYou
+	}
+
+	address = PostalAddress{
+		Street:     strings.TrimSpace(address.Street),
+		PostalCode: strings.TrimSpace(address.PostalCode),
+		City:       strings.TrimSpace(address.City),
+		Country:    strings.TrimSpace(address.Country),
+	}
+	if len(address.Street) > 300 || len(address.PostalCode) > 20 || len(address.City) > 100 || len(address.Country) > 100 {
+		return ErrInvalidAddress
+	}
+
+	t.Gift = gift
+	t.Note = note
+	t.Address = address
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsSent indique si la carte de remerciement a été envoyée
+func (t *ThankYou) IsSent() bool {
+	return !t.SentAt.IsZero()
+}
+
+// MarkSent enregistre l'envoi de la carte à la date donnée (zéro : annule l'envoi)
+func (t *ThankYou) MarkSent(at time.Time) {
+	t.SentAt = at
+	t.UpdatedAt = time.Now()
+}
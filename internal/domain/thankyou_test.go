@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewThankYou(t *testing.T) {
+	tests := []struct {
+		name         string
+		invitationID string
+		rsvpID       string
+		wantErr      error
+	}{
+		{"invitation", "inv-1", "", nil},
+		{"answer without invitation", "", "rsvp-1", nil},
+		{"no household", "", "", ErrInvalidThankYou},
+		{"both", "inv-1", "rsvp-1", ErrInvalidThankYou},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewThankYou(tt.invitationID, tt.rsvpID); err != tt.wantErr {
+				t.Errorf("NewThankYou() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestThankYouUpdate(t *testing.T) {
+	thanks, _ := NewThankYou("inv-1", "")
+
+	address := PostalAddress{Street: " 12 rue des Lilas ", PostalCode: "67000", City: "Strasbourg "}
+	if err := thanks.Update(" Service à thé ", "Merci pour le discours", address); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if thanks.Gift != "Service à thé" || thanks.Address.Street != "12 rue des Lilas" || thanks.Address.City != "Strasbourg" {
+		t.Errorf("Update() = %+v, want trimmed values", thanks)
+	}
+
+	if err := thanks.Update(strings.Repeat("a", 501), "", address); err != ErrInvalidThankYou {
+		t.Errorf("Update() long gift error = %v, want %v", err, ErrInvalidThankYou)
+	}
+	if err := thanks.Update("", "", PostalAddress{PostalCode: strings.Repeat("1", 21)}); err != ErrInvalidAddress {
+		t.Errorf("Update() long postal code error = %v, want %v", err, ErrInvalidAddress)
+	}
+	if thanks.Gift != "Service à thé" {
+		t.Errorf("Gift = %q, want unchanged after a rejected update", thanks.Gift)
+	}
+
+	if thanks.IsSent() {
+		t.Error("IsSent() = true before MarkSent")
+	}
+	thanks.MarkSent(time.Now())
+	if !thanks.IsSent() {
+		t.Error("IsSent() = false after MarkSent")
+	}
+	thanks.MarkSent(time.Time{})
+	if thanks.IsSent() {
+		t.Error("IsSent() = true after cancelling")
+	}
+}
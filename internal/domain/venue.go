@@ -0,0 +1,93 @@
+package domain
+
+// Venue représente un lieu du mariage
+type Venue struct {
+	ID           string
+	Name         string
+	Address      string
+	Latitude     float64 // Coordonnées WGS84 (0,0 si inconnues)
+	Longitude    float64
+	ParkingNotes string
+	// Translations traduit le nom et les indications de stationnement
+	// (rédigés en français) par code de langue ("de", "en")
+	Translations map[string]VenueText
+}
+
+// VenueText est la traduction des textes d'un lieu
+type VenueText struct {
+	Name         string
+	ParkingNotes string
+}
+
+// In retourne une copie du lieu avec ses textes dans la langue donnée ; un
+// texte non traduit reste en français
+func (v *Venue) In(lang string) *Venue {
+	venue := *v
+	if text, ok := v.Translations[lang]; ok {
+		if text.Name != "" {
+			venue.Name = text.Name
+		}
+		if text.ParkingNotes != "" {
+			venue.ParkingNotes = text.ParkingNotes
+		}
+	}
+	return &venue
+}
+
+// HasCoordinates indique si le lieu est géolocalisé
+func (v *Venue) HasCoordinates() bool {
+	return v.Latitude != 0 || v.Longitude != 0
+}
+
+// GetDefaultVenues retourne les lieux par défaut.
+// Les coordonnées sont relevées une fois pour toutes sur OpenStreetMap :
+// aucun service de géocodage n'est appelé à l'exécution.
+func GetDefaultVenues() []*Venue {
+	return []*Venue{
+		{
+			ID:           "mairie",
+			Name:         "Mairie",
+			Address:      "13 Rue de la Mairie, 77930 Cély",
+			Latitude:     48.45965,
+			Longitude:    2.52840,
+			ParkingNotes: "Se garer dans le parking de la mairie",
+			Translations: map[string]VenueText{
+				"de": {Name: "Rathaus", ParkingNotes: "Parken auf dem Parkplatz des Rathauses"},
+				"en": {Name: "Town hall", ParkingNotes: "Park in the town hall car park"},
+			},
+		},
+		{
+			ID:        "chez-nous",
+			Name:      "Chez nous",
+			Address:   "8 rue du bois beaudoin, 77930 Cély",
+			Latitude:  48.45794,
+			Longitude: 2.52391,
+			Translations: map[string]VenueText{
+				"de": {Name: "Bei uns zu Hause"},
+				"en": {Name: "Our home"},
+			},
+		},
+		{
+			ID:           "bergerie",
+			Name:         "La Bergerie",
+			Address:      "Rue de la Bascule, 77190 Villiers-en-Bière",
+			Latitude:     48.48103,
+			Longitude:    2.59590,
+			ParkingNotes: "Un grand parking est disponible sur place.",
+			Translations: map[string]VenueText{
+				"de": {ParkingNotes: "Ein großer Parkplatz steht vor Ort zur Verfügung."},
+				"en": {ParkingNotes: "A large car park is available on site."},
+			},
+		},
+	}
+}
+
+// findVenue retourne le lieu correspondant à l'identifiant
+func findVenue(venues []*Venue, id string) *Venue {
+	for _, venue := range venues {
+		if venue.ID == id {
+			return venue
+		}
+	}
+	return nil
+}
@@ -0,0 +1,259 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// embeddedLocales contient les catalogues livrés avec le binaire, utilisés
+// à défaut de fichiers externes (et pour les clés absentes de ceux-ci)
+//
+//go:embed locales/*.json
+var embeddedLocales embed.FS
+
+// message est une traduction : un texte simple, ou des formes plurielles
+// par catégorie CLDR ("one", "few", "many", "other"...)
+type message struct {
+	text  string
+	forms map[string]string
+}
+
+// UnmarshalJSON accepte une chaîne ou un objet de formes plurielles
+func (m *message) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &m.text); err == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, &m.forms); err != nil {
+		return err
+	}
+	if _, ok := m.forms["other"]; !ok {
+		return errors.New("forme plurielle \"other\" manquante")
+	}
+	return nil
+}
+
+// catalog associe chaque clé à sa traduction
+type catalog map[string]message
+
+// watchInterval espace les vérifications des fichiers en mode dev : le
+// rendu d'une page appelle T() des centaines de fois
+const watchInterval = time.Second
+
+// catalogs est l'ensemble des catalogues chargés, rechargeable à chaud
+var catalogs = struct {
+	sync.RWMutex
+	byLang    map[Lang]catalog
+	dir       string               // Répertoire des fichiers externes (vide : catalogues intégrés)
+	watch     bool                 // Recharger les fichiers modifiés (mode dev)
+	modTime   map[string]time.Time // Date de modification des fichiers chargés
+	checkedAt time.Time            // Dernière vérification des fichiers
+}{
+	byLang: mustLoadEmbedded(),
+}
+
+// Load charge les catalogues <lang>.json du répertoire dir, complétés par
+// les catalogues intégrés. Un répertoire vide garde les catalogues
+// intégrés. Avec watch, les fichiers modifiés sont rechargés sans
+// redémarrage.
+func Load(dir string, watch bool) error {
+	byLang, modTime, err := loadCatalogs(dir)
+	if err != nil {
+		return err
+	}
+
+	catalogs.Lock()
+	defer catalogs.Unlock()
+	catalogs.byLang = byLang
+	catalogs.dir = dir
+	catalogs.watch = watch && dir != ""
+	catalogs.modTime = modTime
+	catalogs.checkedAt = time.Now()
+	return nil
+}
+
+// catalogFor retourne le catalogue d'une langue
+func catalogFor(lang Lang) catalog {
+	return loaded()[lang]
+}
+
+// loaded retourne les catalogues chargés. En mode dev, les fichiers
+// modifiés sont rechargés, vérifiés au plus une fois par watchInterval.
+func loaded() map[Lang]catalog {
+	catalogs.RLock()
+	byLang := catalogs.byLang
+	check := catalogs.watch && time.Since(catalogs.checkedAt) >= watchInterval
+	catalogs.RUnlock()
+	if !check {
+		return byLang
+	}
+
+	catalogs.Lock()
+	defer catalogs.Unlock()
+	if time.Since(catalogs.checkedAt) < watchInterval {
+		return catalogs.byLang // Vérifié entre-temps par une autre requête
+	}
+	catalogs.checkedAt = time.Now()
+
+	if catalogsChanged(catalogs.dir, catalogs.modTime) {
+		if byLang, modTime, err := loadCatalogs(catalogs.dir); err != nil {
+			log.Printf("Traductions non rechargées: %v", err)
+			catalogs.modTime = modTime // Ne pas réessayer avant la prochaine modification
+		} else {
+			catalogs.byLang, catalogs.modTime = byLang, modTime
+		}
+	}
+	return catalogs.byLang
+}
+
+// loadCatalogs charge les catalogues intégrés puis les fichiers du
+// répertoire, qui les remplacent clé par clé
+func loadCatalogs(dir string) (map[Lang]catalog, map[string]time.Time, error) {
+	byLang, err := loadEmbedded()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	modTime := map[string]time.Time{}
+	if dir == "" {
+		return byLang, modTime, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		modTime[path] = info.ModTime()
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		var external catalog
+		if err := json.Unmarshal(data, &external); err != nil {
+			return nil, modTime, fmt.Errorf("%s: %w", path, err)
+		}
+
+		// Un fichier sans équivalent intégré ajoute une langue
+		lang := langFromFile(path)
+		if byLang[lang] == nil {
+			byLang[lang] = catalog{}
+		}
+		for key, msg := range external {
+			byLang[lang][key] = msg
+		}
+	}
+	return byLang, modTime, nil
+}
+
+// catalogsChanged indique si un fichier de catalogue a été ajouté, supprimé
+// ou modifié
+func catalogsChanged(dir string, modTime map[string]time.Time) bool {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil || len(paths) != len(modTime) {
+		return true
+	}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return true
+		}
+		if loaded, ok := modTime[path]; !ok || !info.ModTime().Equal(loaded) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadEmbedded décode les catalogues intégrés au binaire
+func loadEmbedded() (map[Lang]catalog, error) {
+	paths, err := fs.Glob(embeddedLocales, "locales/*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	byLang := map[Lang]catalog{}
+	for _, path := range paths {
+		data, err := embeddedLocales.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var c catalog
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		byLang[langFromFile(path)] = c
+	}
+	if byLang[Default] == nil {
+		return nil, fmt.Errorf("catalogue %s.json manquant", Default)
+	}
+	return byLang, nil
+}
+
+// langFromFile retourne la langue d'un fichier de catalogue (<lang>.json)
+func langFromFile(path string) Lang {
+	return Lang(strings.ToLower(strings.TrimSuffix(filepath.Base(path), ".json")))
+}
+
+// mustLoadEmbedded charge les catalogues intégrés au démarrage : une erreur
+// est un défaut de construction du binaire
+func mustLoadEmbedded() map[Lang]catalog {
+	byLang, err := loadEmbedded()
+	if err != nil {
+		panic(err)
+	}
+	return byLang
+}
+
+// Locale décrit une langue disponible, pour le sélecteur de langue
+type Locale struct {
+	Code Lang
+	Name string // Nom de la langue dans cette langue ("Deutsch")
+}
+
+// Locales retourne les langues disponibles : la langue par défaut d'abord,
+// puis les autres par code
+func Locales() []Locale {
+	byLang := loaded()
+	locales := make([]Locale, 0, len(byLang))
+	for lang, c := range byLang {
+		name := c["lang.name"].text
+		if name == "" {
+			name = strings.ToUpper(string(lang))
+		}
+		locales = append(locales, Locale{Code: lang, Name: name})
+	}
+	sort.Slice(locales, func(i, j int) bool {
+		if (locales[i].Code == Default) != (locales[j].Code == Default) {
+			return locales[i].Code == Default
+		}
+		return locales[i].Code < locales[j].Code
+	})
+	return locales
+}
+
+// Supported indique si une langue a un catalogue
+func Supported(lang Lang) bool {
+	return loaded()[lang] != nil
+}
+
+// Parse retourne la langue correspondant à un code ("de", "DE"), si elle
+// est disponible
+func Parse(code string) (Lang, bool) {
+	lang := Lang(strings.ToLower(strings.TrimSpace(code)))
+	return lang, lang != "" && Supported(lang)
+}
@@ -0,0 +1,138 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// templateKeyPattern repère les appels {{T .T "clé"}}, {{T $.T "clé"}} ou
+	// {{T $root.T "clé"}} des templates
+	templateKeyPattern = regexp.MustCompile(`\bT \$?\w*\.T "([^"]+)"`)
+	// codeKeyPattern repère les appels t.T("clé", ...) du code Go
+	codeKeyPattern = regexp.MustCompile(`\.T\("([a-z][a-z0-9_.]*)"\s*[,)]`)
+	// literalPattern repère les chaînes du code Go qui peuvent être des clés
+	// ("error.invalid_name") ou des préfixes de clés ("date.month.")
+	literalPattern = regexp.MustCompile(`"([a-z][a-z0-9_]*(?:\.[a-z0-9_]+)+\.?)"`)
+)
+
+// Report est le bilan de couverture des traductions
+type Report struct {
+	Missing map[Lang][]string // Clés utilisées ou traduites dans une autre langue, absentes de celle-ci
+	Unused  []string          // Clés traduites mais utilisées nulle part
+}
+
+// OK indique qu'aucune traduction ne manque
+func (r Report) OK() bool {
+	return len(r.Missing) == 0
+}
+
+// CheckCoverage compare les catalogues chargés aux clés utilisées par les
+// templates de templatesDir et par le code Go de sourceDirs : clés absentes
+// d'une langue, et clés traduites que rien n'utilise. Une chaîne du code
+// terminée par un point ("date.month.") utilise toutes les clés qu'elle
+// préfixe.
+func CheckCoverage(templatesDir string, sourceDirs ...string) (Report, error) {
+	byLang := loaded()
+
+	known := map[string]bool{}
+	for _, c := range byLang {
+		for key := range c {
+			known[key] = true
+		}
+	}
+
+	// Clés utilisées : celles des appels T doivent exister, les autres
+	// chaînes du code ne comptent que si ce sont des clés connues
+	required := map[string]bool{}
+	used := map[string]bool{}
+	var prefixes []string
+
+	err := scanFiles(templatesDir, ".html", func(content string) {
+		for _, match := range templateKeyPattern.FindAllStringSubmatch(content, -1) {
+			required[match[1]] = true
+		}
+	})
+	if err != nil {
+		return Report{}, err
+	}
+	for _, dir := range sourceDirs {
+		err := scanFiles(dir, ".go", func(content string) {
+			for _, match := range codeKeyPattern.FindAllStringSubmatch(content, -1) {
+				required[match[1]] = true
+			}
+			for _, match := range literalPattern.FindAllStringSubmatch(content, -1) {
+				switch literal := match[1]; {
+				case strings.HasSuffix(literal, "."):
+					prefixes = append(prefixes, literal)
+				case known[literal]:
+					used[literal] = true
+				}
+			}
+		})
+		if err != nil {
+			return Report{}, err
+		}
+	}
+
+	report := Report{Missing: map[Lang][]string{}}
+	for lang, c := range byLang {
+		for key := range required {
+			if _, ok := c[key]; !ok {
+				report.Missing[lang] = append(report.Missing[lang], key)
+			}
+		}
+		for key := range known {
+			if _, ok := c[key]; !ok && !required[key] {
+				report.Missing[lang] = append(report.Missing[lang], key)
+			}
+		}
+		sort.Strings(report.Missing[lang])
+	}
+	for lang, keys := range report.Missing {
+		if len(keys) == 0 {
+			delete(report.Missing, lang)
+		}
+	}
+
+	for key := range known {
+		if !required[key] && !used[key] && !hasAnyPrefix(key, prefixes) {
+			report.Unused = append(report.Unused, key)
+		}
+	}
+	sort.Strings(report.Unused)
+	return report, nil
+}
+
+// scanFiles lit les fichiers d'une extension donnée sous un répertoire,
+// hors tests
+func scanFiles(dir, ext string, scan func(content string)) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ext || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		scan(string(data))
+		return nil
+	})
+}
+
+// hasAnyPrefix indique si une clé commence par l'un des préfixes
+func hasAnyPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
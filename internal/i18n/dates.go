@@ -0,0 +1,46 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Les noms des mois et des jours, et l'ordre des éléments d'une date, sont
+// des traductions comme les autres : clés date.month.<1-12>,
+// date.weekday.<0-6> (0 : dimanche) et date.format.<forme>, avec les
+// paramètres {day}, {month}, {weekday}, {year}, {hour} et {minute}.
+
+// FormatDate formate une date selon la langue ("11 juillet 2026")
+func (t *Translations) FormatDate(date time.Time) string {
+	return t.formatDate("date.format.date", date)
+}
+
+// FormatLongDate formate une date avec le jour de la semaine
+// ("samedi 11 juillet 2026")
+func (t *Translations) FormatLongDate(date time.Time) string {
+	return t.formatDate("date.format.long", date)
+}
+
+// FormatTime formate une heure sur 24 heures selon la langue ("14h30")
+func (t *Translations) FormatTime(date time.Time) string {
+	return t.formatDate("date.format.time", date)
+}
+
+// FormatDateTime formate une date/heure selon la langue
+// ("11 juillet 2026 à 14h30")
+func (t *Translations) FormatDateTime(date time.Time) string {
+	return t.T("date.format.datetime", "date", t.FormatDate(date), "time", t.FormatTime(date))
+}
+
+// formatDate remplace les paramètres d'un format de date
+func (t *Translations) formatDate(format string, date time.Time) string {
+	return t.T(format,
+		"day", date.Day(),
+		"month", t.T("date.month."+strconv.Itoa(int(date.Month()))),
+		"weekday", t.T("date.weekday."+strconv.Itoa(int(date.Weekday()))),
+		"year", date.Year(),
+		"hour", fmt.Sprintf("%02d", date.Hour()),
+		"minute", fmt.Sprintf("%02d", date.Minute()),
+	)
+}
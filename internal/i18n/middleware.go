@@ -1,36 +1,62 @@
 package i18n
 
 import (
+	"context"
 	"net/http"
+	"strings"
 )
 
 const (
 	// LangCookieName est le nom du cookie de langue
 	LangCookieName = "wedding_lang"
-	// LangContextKey est la clé de contexte pour la langue
+	// LangContextKey est la clé de contexte pour la langue du préfixe d'URL
 	LangContextKey = "lang"
 )
 
-// GetLangFromRequest extrait la langue depuis la requête (cookie ou query param)
+// contextKey est le type des clés de contexte du package
+type contextKey string
+
+// GetLangFromRequest extrait la langue depuis la requête : voir RequestLang
 func GetLangFromRequest(r *http.Request) Lang {
+	lang, _ := RequestLang(r)
+	return lang
+}
+
+// RequestLang extrait la langue depuis la requête, dans l'ordre : query
+// param ?lang=de, préfixe d'URL (/de/...), cookie, puis en-tête
+// Accept-Language si la négociation est activée. chosen indique un choix
+// explicite du visiteur, à mémoriser dans le cookie ; une langue non
+// disponible donne la langue par défaut.
+func RequestLang(r *http.Request) (lang Lang, chosen bool) {
 	// 1. Vérifier le query param ?lang=de
 	if langParam := r.URL.Query().Get("lang"); langParam != "" {
-		if langParam == string(DE) {
-			return DE
+		if lang, ok := Parse(langParam); ok {
+			return lang, true
 		}
-		return FR
+		return Default, true
+	}
+
+	// 2. Vérifier le préfixe d'URL
+	if lang, ok := r.Context().Value(contextKey(LangContextKey)).(Lang); ok {
+		return lang, true
 	}
 
-	// 2. Vérifier le cookie
+	// 3. Vérifier le cookie
 	if cookie, err := r.Cookie(LangCookieName); err == nil {
-		if cookie.Value == string(DE) {
-			return DE
+		if lang, ok := Parse(cookie.Value); ok {
+			return lang, true
 		}
-		return FR
 	}
 
-	// 3. Par défaut : français (on ignore Accept-Language pour toujours démarrer en français)
-	return FR
+	// 4. Langue du navigateur, si ce déploiement l'accepte
+	if currentOptions().AcceptLanguage {
+		if lang, ok := Negotiate(r.Header.Get("Accept-Language")); ok {
+			return lang, false
+		}
+	}
+
+	// 5. Par défaut : français
+	return Default, false
 }
 
 // SetLangCookie définit le cookie de langue
@@ -46,3 +72,41 @@ func SetLangCookie(w http.ResponseWriter, lang Lang) {
 	}
 	http.SetCookie(w, cookie)
 }
+
+// URLPrefixHandler retire le préfixe de langue des URL (/de/planning sert
+// /planning en allemand) quand ce routage est activé
+func URLPrefixHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !currentOptions().URLPrefix {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		segment, rest, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		lang := Lang(segment)
+		if segment == "" || segment != strings.ToLower(segment) || !Supported(lang) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		r2 := r.WithContext(context.WithValue(r.Context(), contextKey(LangContextKey), lang))
+		url := *r.URL
+		url.Path = "/" + rest
+		url.RawPath = ""
+		r2.URL = &url
+		next.ServeHTTP(w, r2)
+	})
+}
+
+// URL retourne l'adresse d'une page dans une langue : /de/planning avec le
+// routage par préfixe, /planning?lang=de sinon. Un chemin vide désigne la
+// page courante.
+func URL(lang Lang, path string) string {
+	if currentOptions().URLPrefix {
+		if path == "" {
+			path = "/"
+		}
+		return "/" + string(lang) + path
+	}
+	return path + "?lang=" + string(lang)
+}
@@ -0,0 +1,85 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Options règle le choix de la langue des visiteurs
+type Options struct {
+	AcceptLanguage bool // Langue du navigateur à défaut de choix explicite (sinon langue par défaut)
+	URLPrefix      bool // Préfixe de langue dans les URL (/de/planning)
+	ReportMissing  bool // Journaliser et signaler à l'écran les traductions manquantes (dev)
+}
+
+var options = struct {
+	sync.RWMutex
+	Options
+}{
+	Options: Options{AcceptLanguage: true},
+}
+
+// Configure définit le choix de la langue des visiteurs pour ce déploiement
+func Configure(opts Options) {
+	options.Lock()
+	defer options.Unlock()
+	options.Options = opts
+}
+
+// currentOptions retourne le choix de la langue configuré
+func currentOptions() Options {
+	options.RLock()
+	defer options.RUnlock()
+	return options.Options
+}
+
+// Negotiate choisit la langue disponible préférée d'un en-tête
+// Accept-Language ("de-AT,de;q=0.9,en;q=0.8"). Une variante régionale
+// donne sa langue (de-AT : de).
+func Negotiate(header string) (Lang, bool) {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue // q=0 : langue refusée
+		}
+		tags = append(tags, weighted{tag, q})
+	}
+
+	// À qualité égale, l'ordre de l'en-tête est conservé
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	for _, t := range tags {
+		if lang, ok := Parse(t.tag); ok {
+			return lang, true
+		}
+		if base, _, regional := strings.Cut(strings.ReplaceAll(t.tag, "_", "-"), "-"); regional {
+			if lang, ok := Parse(base); ok {
+				return lang, true
+			}
+		}
+	}
+	return "", false
+}
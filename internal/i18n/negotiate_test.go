@@ -0,0 +1,107 @@
+package i18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Lang
+		ok     bool
+	}{
+		{"de", DE, true},
+		{"de-AT,de;q=0.9", DE, true},
+		{"en-GB,en;q=0.9,fr;q=0.8", EN, true},
+		{"it,de;q=0.5,en;q=0.7", EN, true},
+		{"fr;q=0.2, de-CH;q=0.8", DE, true},
+		{"de;q=0, en", EN, true},
+		{"es, it", "", false},
+		{"*", "", false},
+		{"de;q=abc", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Negotiate(tt.header)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Negotiate(%q) = %q, %v, want %q, %v", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRequestLang(t *testing.T) {
+	t.Cleanup(func() { Configure(Options{AcceptLanguage: true}) })
+
+	request := func(target, cookie, acceptLanguage string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		if cookie != "" {
+			r.AddCookie(&http.Cookie{Name: LangCookieName, Value: cookie})
+		}
+		r.Header.Set("Accept-Language", acceptLanguage)
+		return r
+	}
+
+	Configure(Options{AcceptLanguage: true})
+	tests := []struct {
+		r          *http.Request
+		want       Lang
+		wantChosen bool
+	}{
+		{request("/", "", "de-AT,de;q=0.9"), DE, false},
+		{request("/", "en", "de-AT"), EN, true},
+		{request("/?lang=fr", "en", "de-AT"), FR, true},
+		{request("/", "", "es"), Default, false},
+		{request("/", "xx", "en"), EN, false},
+	}
+	for _, tt := range tests {
+		if got, chosen := RequestLang(tt.r); got != tt.want || chosen != tt.wantChosen {
+			t.Errorf("RequestLang(%s, %v) = %q, %v, want %q, %v", tt.r.URL, tt.r.Cookies(), got, chosen, tt.want, tt.wantChosen)
+		}
+	}
+
+	// Négociation désactivée : la langue par défaut sans choix explicite
+	Configure(Options{AcceptLanguage: false})
+	if got, _ := RequestLang(request("/", "", "de")); got != Default {
+		t.Errorf("RequestLang() without negotiation = %q, want the default language", got)
+	}
+}
+
+func TestURLPrefixHandler(t *testing.T) {
+	t.Cleanup(func() { Configure(Options{AcceptLanguage: true}) })
+	Configure(Options{URLPrefix: true})
+
+	var gotPath string
+	var gotLang Lang
+	handler := URLPrefixHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotLang = r.URL.Path, GetLangFromRequest(r)
+	}))
+
+	tests := []struct {
+		target   string
+		wantPath string
+		wantLang Lang
+	}{
+		{"/de/planning", "/planning", DE},
+		{"/en", "/", EN},
+		{"/en/", "/", EN},
+		{"/planning", "/planning", Default},
+		{"/static/css/style.css", "/static/css/style.css", Default},
+		{"/it/planning", "/it/planning", Default},
+	}
+	for _, tt := range tests {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.target, nil))
+		if gotPath != tt.wantPath || gotLang != tt.wantLang {
+			t.Errorf("%s: path %q, lang %q, want %q, %q", tt.target, gotPath, gotLang, tt.wantPath, tt.wantLang)
+		}
+	}
+
+	if got := URL(DE, "/planning"); got != "/de/planning" {
+		t.Errorf("URL(de, /planning) = %q, want /de/planning", got)
+	}
+	Configure(Options{})
+	if got := URL(DE, "/planning"); got != "/planning?lang=de" {
+		t.Errorf("URL(de, /planning) = %q, want /planning?lang=de", got)
+	}
+}
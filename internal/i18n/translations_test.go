@@ -0,0 +1,220 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTranslationCoverage(t *testing.T) {
+	report, err := CheckCoverage(filepath.Join("..", "..", "web", "templates"), filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for lang, keys := range report.Missing {
+		for _, key := range keys {
+			t.Errorf("key %q missing in %s translations", key, lang)
+		}
+	}
+	for _, key := range report.Unused {
+		t.Errorf("key %q is translated but never used", key)
+	}
+}
+
+func TestCheckCoverageReportsMissingAndUnusedKeys(t *testing.T) {
+	templates, src := t.TempDir(), t.TempDir()
+	os.WriteFile(filepath.Join(templates, "page.html"), []byte(`{{T .T "nav.home"}} {{T $root.T "page.unknown"}}`), 0644)
+	os.WriteFile(filepath.Join(src, "code.go"), []byte(`package x
+var a = t.T("rsvp.title")
+var b = t.T("date.month." + month)
+`), 0644)
+
+	report, err := CheckCoverage(templates, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, locale := range Locales() {
+		if missing := report.Missing[locale.Code]; len(missing) != 1 || missing[0] != "page.unknown" {
+			t.Errorf("Missing[%s] = %v, want [page.unknown]", locale.Code, missing)
+		}
+	}
+	for _, key := range report.Unused {
+		if key == "nav.home" || key == "rsvp.title" || key == "date.month.7" {
+			t.Errorf("key %q is used but reported as unused", key)
+		}
+	}
+	if len(report.Unused) == 0 {
+		t.Error("keys used nowhere should be reported as unused")
+	}
+}
+
+func TestMissingTranslationFallback(t *testing.T) {
+	t.Cleanup(func() { Configure(Options{AcceptLanguage: true}) })
+
+	de := &Translations{lang: DE, data: catalog{}}
+	if got := de.T("carpool.seat_count", "count", 0); got != "0 place" {
+		t.Errorf("T() of a missing key = %q, want the default language text", got)
+	}
+
+	Configure(Options{ReportMissing: true})
+	if got := de.T("carpool.seat_count"); got != "⟦carpool.seat_count⟧" {
+		t.Errorf("T() of a missing key in dev = %q, want a visible marker", got)
+	}
+}
+
+func TestLocales(t *testing.T) {
+	locales := Locales()
+	want := []Locale{{FR, "Français"}, {DE, "Deutsch"}, {EN, "English"}}
+	if len(locales) != len(want) {
+		t.Fatalf("Locales() = %v, want %v", locales, want)
+	}
+	for i := range want {
+		if locales[i] != want[i] {
+			t.Errorf("Locales()[%d] = %v, want %v", i, locales[i], want[i])
+		}
+	}
+
+	if lang, ok := Parse("EN"); !ok || lang != EN {
+		t.Errorf("Parse(EN) = %q, %v, want en, true", lang, ok)
+	}
+	if _, ok := Parse("it"); ok {
+		t.Error("Parse(it) should fail without an Italian catalog")
+	}
+	if got := NewTranslations("it").Lang(); got != string(Default) {
+		t.Errorf("NewTranslations(it).Lang() = %q, want the default language", got)
+	}
+}
+
+func TestTranslationsPluralsAndPlaceholders(t *testing.T) {
+	fr, de, en := NewTranslations(FR), NewTranslations(DE), NewTranslations(EN)
+
+	tests := []struct {
+		t    *Translations
+		key  string
+		args []interface{}
+		want string
+	}{
+		{fr, "carpool.seat_count", []interface{}{"count", 0}, "0 place"},
+		{fr, "carpool.seat_count", []interface{}{"count", 1}, "1 place"},
+		{fr, "carpool.seat_count", []interface{}{"count", 3}, "3 places"},
+		{de, "carpool.seat_count", []interface{}{"count", 0}, "0 Plätze"},
+		{de, "carpool.seat_count", []interface{}{"count", 1}, "1 Platz"},
+		{en, "carpool.seat_count", []interface{}{"count", 0}, "0 seats"},
+		{en, "print.persons", []interface{}{"count", 1}, "1 person"},
+		{fr, "calendar.name_personal", []interface{}{"name", "Famille Dupont"}, "Mariage - Famille Dupont"},
+		{de, "print.guest_of", []interface{}{"name", "Anna"}, "Gast von Anna"},
+		{fr, "calendar.name_personal", nil, "Mariage - {name}"},
+		{fr, "unknown.key", []interface{}{"count", 2}, "unknown.key"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.t.T(tt.key, tt.args...); got != tt.want {
+			t.Errorf("%s.T(%q, %v) = %q, want %q", tt.t.Lang(), tt.key, tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		lang Lang
+		n    int
+		want string
+	}{
+		{FR, 0, "one"}, {FR, 1, "one"}, {FR, 2, "other"}, {FR, 1000000, "many"},
+		{DE, 0, "other"}, {DE, 1, "one"}, {DE, 2, "other"},
+		{EN, 0, "other"}, {EN, 1, "one"},
+	}
+	for _, tt := range tests {
+		if got := pluralCategory(tt.lang, tt.n); got != tt.want {
+			t.Errorf("pluralCategory(%s, %d) = %q, want %q", tt.lang, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestLoadExternalCatalogs(t *testing.T) {
+	t.Cleanup(func() { Load("", false) })
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "fr.json")
+	if err := os.WriteFile(path, []byte(`{"nav.home": "Bienvenue"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Load(dir, true); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	fr := NewTranslations(FR)
+	if got := fr.T("nav.home"); got != "Bienvenue" {
+		t.Errorf("T(nav.home) = %q, want the external translation", got)
+	}
+	if got := fr.T("nav.rsvp"); got == "nav.rsvp" {
+		t.Error("keys missing from the external file should fall back to the embedded catalog")
+	}
+
+	// Rechargement à chaud en mode dev
+	if err := os.WriteFile(path, []byte(`{"nav.home": "Accueil modifié"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	os.Chtimes(path, later, later)
+	if got := NewTranslations(FR).T("nav.home"); got != "Bienvenue" {
+		t.Errorf("T(nav.home) right after edit = %q, want files checked at most once per %s", got, watchInterval)
+	}
+	expireWatch()
+	if got := NewTranslations(FR).T("nav.home"); got != "Accueil modifié" {
+		t.Errorf("T(nav.home) after edit = %q, want the reloaded translation", got)
+	}
+
+	// Un nouveau fichier ajoute une langue
+	if err := os.WriteFile(filepath.Join(dir, "it.json"), []byte(`{"lang.name": "Italiano", "nav.home": "Benvenuti"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	expireWatch()
+	if lang, ok := Parse("it"); !ok || NewTranslations(lang).T("nav.home") != "Benvenuti" {
+		t.Error("a catalog added to the directory should register its language")
+	}
+
+	// Un fichier invalide est refusé au chargement
+	os.WriteFile(path, []byte(`{"nav.home": {"one": "sans other"}}`), 0644)
+	if err := Load(dir, false); err == nil {
+		t.Error("Load() with a plural missing its other form should fail")
+	}
+}
+
+// expireWatch fait comme si la dernière vérification des fichiers en mode
+// dev remontait à plus de watchInterval
+func expireWatch() {
+	catalogs.Lock()
+	defer catalogs.Unlock()
+	catalogs.checkedAt = time.Time{}
+}
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2026, time.July, 11, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		lang                       Lang
+		wantDate, wantLong, wantDT string
+	}{
+		{FR, "11 juillet 2026", "samedi 11 juillet 2026", "11 juillet 2026 à 14h30"},
+		{DE, "11. Juli 2026", "Samstag, 11. Juli 2026", "11. Juli 2026 um 14:30 Uhr"},
+		{EN, "July 11, 2026", "Saturday, July 11, 2026", "July 11, 2026 at 14:30"},
+	}
+	for _, tt := range tests {
+		tr := NewTranslations(tt.lang)
+		if got := tr.FormatDate(date); got != tt.wantDate {
+			t.Errorf("%s FormatDate() = %q, want %q", tt.lang, got, tt.wantDate)
+		}
+		if got := tr.FormatLongDate(date); got != tt.wantLong {
+			t.Errorf("%s FormatLongDate() = %q, want %q", tt.lang, got, tt.wantLong)
+		}
+		if got := tr.FormatDateTime(date); got != tt.wantDT {
+			t.Errorf("%s FormatDateTime() = %q, want %q", tt.lang, got, tt.wantDT)
+		}
+	}
+
+	if got := NewTranslations(FR).FormatTime(time.Date(2026, time.July, 11, 9, 5, 0, 0, time.UTC)); got != "09h05" {
+		t.Errorf("FormatTime() = %q, want 09h05", got)
+	}
+}
@@ -0,0 +1,240 @@
+// Package imaging prépare les photos envoyées par les invités, sans
+// dépendance externe.
+//
+// Les images JPEG et PNG sont décodées, redressées selon leur orientation
+// EXIF, réduites puis réencodées en JPEG. Le réencodage supprime toutes les
+// métadonnées (EXIF, GPS, modèle d'appareil...) : seuls les pixels sont
+// conservés.
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png" // Décodeur PNG enregistré pour image.Decode
+)
+
+var (
+	ErrUnsupportedImage = errors.New("image illisible ou format non supporté")
+	ErrImageTooLarge    = errors.New("image trop grande")
+)
+
+// maxPixels limite la définition acceptée (protection contre les images
+// « bombes » qui occupent des gigaoctets une fois décodées)
+const maxPixels = 50_000_000
+
+// jpegQuality est la qualité des images réencodées
+const jpegQuality = 85
+
+// Result contient la photo et sa miniature réencodées en JPEG
+type Result struct {
+	Photo     []byte
+	Thumbnail []byte
+	Width     int // Dimensions de la photo, après redressement
+	Height    int
+}
+
+// Process décode une image JPEG ou PNG, la redresse et la réencode en JPEG
+// sans métadonnées, réduite pour tenir dans maxSide pixels, avec une
+// miniature tenant dans thumbSide pixels.
+func Process(data []byte, maxSide, thumbSide int) (*Result, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || (format != "jpeg" && format != "png") {
+		return nil, ErrUnsupportedImage
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	orientation := 1
+	if format == "jpeg" {
+		orientation = exifOrientation(data)
+	}
+
+	photo := orient(fit(flatten(src), maxSide), orientation)
+	thumbnail := fit(photo, thumbSide)
+
+	result := &Result{
+		Width:  photo.Bounds().Dx(),
+		Height: photo.Bounds().Dy(),
+	}
+	if result.Photo, err = encode(photo); err != nil {
+		return nil, err
+	}
+	if result.Thumbnail, err = encode(thumbnail); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// encode écrit l'image en JPEG (sans aucune métadonnée)
+func encode(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// flatten copie l'image en RGBA sur fond blanc (le JPEG n'a pas de transparence)
+func flatten(src image.Image) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Over)
+	return dst
+}
+
+// fit réduit l'image pour que son plus grand côté ne dépasse pas maxSide,
+// en moyennant les pixels sources couverts par chaque pixel réduit
+func fit(src *image.RGBA, maxSide int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if sw <= maxSide && sh <= maxSide {
+		return src
+	}
+
+	dw, dh := maxSide, sh*maxSide/sw
+	if sh > sw {
+		dw, dh = sw*maxSide/sh, maxSide
+	}
+	dw, dh = max(dw, 1), max(dh, 1)
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for dy := 0; dy < dh; dy++ {
+		y0, y1 := dy*sh/dh, max((dy+1)*sh/dh, dy*sh/dh+1)
+		for dx := 0; dx < dw; dx++ {
+			x0, x1 := dx*sw/dw, max((dx+1)*sw/dw, dx*sw/dw+1)
+
+			var r, g, b, a, n uint32
+			for y := y0; y < y1; y++ {
+				row := src.Pix[y*src.Stride+x0*4 : y*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					r += uint32(row[i])
+					g += uint32(row[i+1])
+					b += uint32(row[i+2])
+					a += uint32(row[i+3])
+					n++
+				}
+			}
+
+			o := dy*dst.Stride + dx*4
+			dst.Pix[o] = uint8(r / n)
+			dst.Pix[o+1] = uint8(g / n)
+			dst.Pix[o+2] = uint8(b / n)
+			dst.Pix[o+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// orient applique l'orientation EXIF (1 à 8) pour redresser l'image
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w // Rotations d'un quart de tour : dimensions échangées
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // Miroir horizontal
+				sx, sy = w-1-x, y
+			case 3: // Demi-tour
+				sx, sy = w-1-x, h-1-y
+			case 4: // Miroir vertical
+				sx, sy = x, h-1-y
+			case 5: // Transposition
+				sx, sy = y, x
+			case 6: // Quart de tour horaire
+				sx, sy = y, h-1-x
+			case 7: // Transposition inverse
+				sx, sy = w-1-y, h-1-x
+			case 8: // Quart de tour anti-horaire
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[y*dst.Stride+x*4:y*dst.Stride+x*4+4], src.Pix[sy*src.Stride+sx*4:sy*src.Stride+sx*4+4])
+		}
+	}
+	return dst
+}
+
+// exifOrientation lit le tag Orientation (0x0112) du segment EXIF d'un JPEG.
+// Retourne 1 (orientation normale) si le tag est absent ou illisible.
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	// Parcourir les segments jusqu'au début des données d'image (SOS)
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if marker == 0xDA || length < 2 || i+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// tiffOrientation lit le tag Orientation dans le premier IFD d'un en-tête TIFF
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[offset : offset+2]))
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			value := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if value < 1 || value > 8 {
+				return 1
+			}
+			return value
+		}
+	}
+	return 1
+}
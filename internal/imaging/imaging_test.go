@@ -0,0 +1,143 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// testImage crée une image grise avec un carré rouge dans le coin haut gauche
+func testImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{128, 128, 128, 255}
+			if x < w/4 && y < h/4 {
+				c = color.RGBA{255, 0, 0, 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+// withExifOrientation insère un segment EXIF (orientation donnée) après le SOI
+func withExifOrientation(t *testing.T, data []byte, orientation uint16) []byte {
+	t.Helper()
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	ifd := make([]byte, 2+12+4)
+	binary.BigEndian.PutUint16(ifd[0:], 1)
+	binary.BigEndian.PutUint16(ifd[2:], 0x0112) // Orientation
+	binary.BigEndian.PutUint16(ifd[4:], 3)      // SHORT
+	binary.BigEndian.PutUint32(ifd[6:], 1)
+	binary.BigEndian.PutUint16(ifd[10:], orientation)
+	payload := append([]byte("Exif\x00\x00"), append(tiff, ifd...)...)
+
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func decode(t *testing.T, data []byte) image.Image {
+	t.Helper()
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil || format != "jpeg" {
+		t.Fatalf("output is not a JPEG: format=%q err=%v", format, err)
+	}
+	return img
+}
+
+func isRed(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return r>>8 > 200 && g>>8 < 60 && b>>8 < 60
+}
+
+func TestProcessResizesAndMakesThumbnail(t *testing.T) {
+	result, err := Process(encodeJPEG(t, testImage(400, 200)), 100, 20)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if result.Width != 100 || result.Height != 50 {
+		t.Errorf("photo size = %dx%d, want 100x50", result.Width, result.Height)
+	}
+	if b := decode(t, result.Photo).Bounds(); b.Dx() != 100 || b.Dy() != 50 {
+		t.Errorf("encoded photo size = %dx%d, want 100x50", b.Dx(), b.Dy())
+	}
+	if b := decode(t, result.Thumbnail).Bounds(); b.Dx() != 20 || b.Dy() != 10 {
+		t.Errorf("thumbnail size = %dx%d, want 20x10", b.Dx(), b.Dy())
+	}
+}
+
+func TestProcessAppliesOrientationAndStripsExif(t *testing.T) {
+	data := withExifOrientation(t, encodeJPEG(t, testImage(80, 40)), 6)
+	if exifOrientation(data) != 6 {
+		t.Fatalf("exifOrientation() = %d, want 6", exifOrientation(data))
+	}
+
+	result, err := Process(data, 1000, 10)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	// Quart de tour horaire : le coin haut gauche passe en haut à droite
+	if result.Width != 40 || result.Height != 80 {
+		t.Errorf("photo size = %dx%d, want 40x80", result.Width, result.Height)
+	}
+	img := decode(t, result.Photo)
+	if !isRed(img.At(36, 3)) || isRed(img.At(3, 3)) {
+		t.Error("photo was not rotated clockwise")
+	}
+
+	if bytes.Contains(result.Photo, []byte("Exif")) || exifOrientation(result.Photo) != 1 {
+		t.Error("photo still contains EXIF metadata")
+	}
+}
+
+func TestProcessFlattensTransparentPNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 10, 10))); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Process(buf.Bytes(), 100, 10)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	r, g, b, _ := decode(t, result.Photo).At(5, 5).RGBA()
+	if r>>8 < 240 || g>>8 < 240 || b>>8 < 240 {
+		t.Errorf("transparent pixel = (%d, %d, %d), want white", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestProcessRejectsUnsupportedImages(t *testing.T) {
+	gif := []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;")
+	for name, data := range map[string][]byte{
+		"garbage": []byte("not an image"),
+		"gif":     gif,
+		"empty":   nil,
+	} {
+		if _, err := Process(data, 100, 10); err != ErrUnsupportedImage {
+			t.Errorf("Process(%s) error = %v, want %v", name, err, ErrUnsupportedImage)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+// Package markdown rend un sous-ensemble sûr de Markdown en HTML.
+//
+// Seuls les éléments utiles aux contenus du site sont pris en charge :
+// paragraphes (retours à la ligne conservés), titres, listes à puces ou
+// numérotées, gras, italique, code et liens. Tout le reste est échappé :
+// le HTML brut n'est jamais interprété et seuls les liens http(s), mailto,
+// tel et relatifs sont rendus cliquables.
+package markdown
+
+import (
+	"html/template"
+	"strings"
+)
+
+// Décalage des titres : "#" est rendu en <h3>, sous le titre de section
+const headingOffset = 2
+
+// Render convertit du Markdown en HTML sûr
+func Render(src string) template.HTML {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+
+	r := &renderer{}
+	for _, line := range strings.Split(src, "\n") {
+		r.line(line)
+	}
+	r.flush()
+
+	return template.HTML(r.out.String())
+}
+
+// listKind identifie le type de liste en cours
+type listKind int
+
+const (
+	noList listKind = iota
+	bulletList
+	orderedList
+)
+
+// renderer accumule les blocs en cours de construction
+type renderer struct {
+	out       strings.Builder
+	paragraph []string
+	list      listKind
+	items     []string
+}
+
+// line traite une ligne de source
+func (r *renderer) line(line string) {
+	trimmed := strings.TrimSpace(line)
+
+	switch {
+	case trimmed == "":
+		r.flush()
+
+	case isHeading(trimmed):
+		r.flush()
+		level := strings.IndexFunc(trimmed, func(c rune) bool { return c != '#' })
+		tag := "h" + string(rune('0'+min(level+headingOffset, 6)))
+		r.out.WriteString("<" + tag + ">" + renderInline(strings.TrimSpace(trimmed[level:])) + "</" + tag + ">\n")
+
+	case isBulletItem(trimmed):
+		r.startList(bulletList)
+		r.items = append(r.items, strings.TrimSpace(trimmed[2:]))
+
+	case orderedItemStart(trimmed) > 0:
+		r.startList(orderedList)
+		r.items = append(r.items, strings.TrimSpace(trimmed[orderedItemStart(trimmed):]))
+
+	case r.list != noList && line != trimmed:
+		// Ligne indentée : suite de l'élément de liste précédent
+		r.items[len(r.items)-1] += " " + trimmed
+
+	default:
+		r.flushList()
+		r.paragraph = append(r.paragraph, trimmed)
+	}
+}
+
+// startList ouvre une liste du type demandé en fermant les blocs différents
+func (r *renderer) startList(kind listKind) {
+	r.flushParagraph()
+	if r.list != kind {
+		r.flushList()
+		r.list = kind
+	}
+}
+
+// flush ferme tous les blocs en cours
+func (r *renderer) flush() {
+	r.flushParagraph()
+	r.flushList()
+}
+
+// flushParagraph écrit le paragraphe en cours, lignes séparées par <br>
+func (r *renderer) flushParagraph() {
+	if len(r.paragraph) == 0 {
+		return
+	}
+	rendered := make([]string, len(r.paragraph))
+	for i, line := range r.paragraph {
+		rendered[i] = renderInline(line)
+	}
+	r.out.WriteString("<p>" + strings.Join(rendered, "<br>\n") + "</p>\n")
+	r.paragraph = nil
+}
+
+// flushList écrit la liste en cours
+func (r *renderer) flushList() {
+	if r.list == noList {
+		return
+	}
+	tag := "ul"
+	if r.list == orderedList {
+		tag = "ol"
+	}
+	r.out.WriteString("<" + tag + ">\n")
+	for _, item := range r.items {
+		r.out.WriteString("<li>" + renderInline(item) + "</li>\n")
+	}
+	r.out.WriteString("</" + tag + ">\n")
+	r.list = noList
+	r.items = nil
+}
+
+// isHeading indique si la ligne est un titre ("# Titre" à "#### Titre")
+func isHeading(line string) bool {
+	level := strings.IndexFunc(line, func(c rune) bool { return c != '#' })
+	return level >= 1 && level <= 4 && line[level] == ' '
+}
+
+// isBulletItem indique si la ligne est un élément de liste à puces
+func isBulletItem(line string) bool {
+	return len(line) > 2 && (line[0] == '-' || line[0] == '*' || line[0] == '+') && line[1] == ' '
+}
+
+// orderedItemStart retourne la position du texte d'un élément de liste
+// numérotée ("1. Texte"), ou 0 si la ligne n'en est pas un
+func orderedItemStart(line string) int {
+	i := 0
+	for i < len(line) && line[i] >= '0' && line[i] <= '9' {
+		i++
+	}
+	if i == 0 || i+1 >= len(line) || line[i] != '.' || line[i+1] != ' ' {
+		return 0
+	}
+	return i + 2
+}
+
+// renderInline rend les éléments en ligne d'un texte et échappe le reste
+func renderInline(s string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte(`\*_`+"`"+`[]()#-+.!`, s[i+1]) >= 0:
+			writeEscaped(&b, s[i+1:i+2])
+			i += 2
+			continue
+
+		case c == '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end > 0 {
+				b.WriteString("<code>")
+				writeEscaped(&b, s[i+1:i+1+end])
+				b.WriteString("</code>")
+				i += end + 2
+				continue
+			}
+
+		case strings.HasPrefix(s[i:], "**"):
+			if end := strings.Index(s[i+2:], "**"); end > 0 {
+				b.WriteString("<strong>" + renderInline(s[i+2:i+2+end]) + "</strong>")
+				i += end + 4
+				continue
+			}
+
+		case c == '*' || (c == '_' && (i == 0 || !isWordChar(s[i-1]))):
+			if end := strings.IndexByte(s[i+1:], c); end > 0 && s[i+1] != ' ' {
+				b.WriteString("<em>" + renderInline(s[i+1:i+1+end]) + "</em>")
+				i += end + 2
+				continue
+			}
+
+		case c == '[':
+			if text, href, n, ok := parseLink(s[i:]); ok {
+				writeLink(&b, text, href)
+				i += n
+				continue
+			}
+		}
+
+		writeEscaped(&b, s[i:i+1])
+		i++
+	}
+
+	return b.String()
+}
+
+// parseLink analyse un lien "[texte](url)" en début de chaîne et retourne
+// le texte, l'URL et la longueur consommée
+func parseLink(s string) (text, href string, n int, ok bool) {
+	closeText := strings.Index(s, "](")
+	if closeText < 1 {
+		return "", "", 0, false
+	}
+	closeURL := strings.IndexByte(s[closeText+2:], ')')
+	if closeURL < 1 {
+		return "", "", 0, false
+	}
+	href = strings.TrimSpace(s[closeText+2 : closeText+2+closeURL])
+	if strings.ContainsAny(href, " \t") {
+		return "", "", 0, false
+	}
+	return s[1:closeText], href, closeText + 3 + closeURL, true
+}
+
+// writeLink écrit un lien si son URL est sûre, sinon seulement son texte
+func writeLink(b *strings.Builder, text, href string) {
+	if !isSafeURL(href) {
+		b.WriteString(renderInline(text))
+		return
+	}
+	b.WriteString(`<a href="`)
+	writeEscaped(b, href)
+	b.WriteString(`"`)
+	if strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://") {
+		b.WriteString(` target="_blank" rel="noopener noreferrer"`)
+	}
+	b.WriteString(">" + renderInline(text) + "</a>")
+}
+
+// isSafeURL n'autorise que les schémas sans risque et les liens relatifs
+func isSafeURL(href string) bool {
+	lower := strings.ToLower(href)
+	for _, prefix := range []string{"http://", "https://", "mailto:", "tel:"} {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	// Liens relatifs au site ("/rsvp") ou ancres ("#hebergement"),
+	// mais pas les URL sans schéma ("//exemple.com")
+	return (strings.HasPrefix(href, "/") && !strings.HasPrefix(href, "//")) || strings.HasPrefix(href, "#")
+}
+
+// isWordChar indique si l'octet fait partie d'un mot (pour "_" intra-mot)
+func isWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+// writeEscaped écrit le texte échappé pour HTML
+func writeEscaped(b *strings.Builder, s string) {
+	b.WriteString(template.HTMLEscapeString(s))
+}
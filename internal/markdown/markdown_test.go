@@ -0,0 +1,91 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "paragraphs keep line breaks",
+			src:  "Première ligne\nDeuxième ligne\n\nAutre paragraphe",
+			want: "<p>Première ligne<br>\nDeuxième ligne</p>\n<p>Autre paragraphe</p>\n",
+		},
+		{
+			name: "headings are shifted below the section title",
+			src:  "# Titre\n## Sous-titre",
+			want: "<h3>Titre</h3>\n<h4>Sous-titre</h4>\n",
+		},
+		{
+			name: "bullet list",
+			src:  "Hôtels :\n- Hôtel de Londres\n* Hôtel Belle\n  Fontainebleau",
+			want: "<p>Hôtels :</p>\n<ul>\n<li>Hôtel de Londres</li>\n<li>Hôtel Belle Fontainebleau</li>\n</ul>\n",
+		},
+		{
+			name: "ordered list",
+			src:  "1. Un\n2. Deux",
+			want: "<ol>\n<li>Un</li>\n<li>Deux</li>\n</ol>\n",
+		},
+		{
+			name: "inline emphasis and code",
+			src:  "**Chic** et *élégant*, code `<b>`",
+			want: "<p><strong>Chic</strong> et <em>élégant</em>, code <code>&lt;b&gt;</code></p>\n",
+		},
+		{
+			name: "underscores inside words are literal",
+			src:  "rsvp_data et _italique_",
+			want: "<p>rsvp_data et <em>italique</em></p>\n",
+		},
+		{
+			name: "external link opens in a new tab",
+			src:  "[Site](https://exemple.com)",
+			want: `<p><a href="https://exemple.com" target="_blank" rel="noopener noreferrer">Site</a></p>` + "\n",
+		},
+		{
+			name: "mailto and relative links",
+			src:  "[Écrire](mailto:a@exemple.com) ou [répondre](/rsvp)",
+			want: `<p><a href="mailto:a@exemple.com">Écrire</a> ou <a href="/rsvp">répondre</a></p>` + "\n",
+		},
+		{
+			name: "unsafe link keeps only its text",
+			src:  "[clic](javascript:alert(1))",
+			want: "<p>clic)</p>\n",
+		},
+		{
+			name: "raw html is escaped",
+			src:  `<script>alert("x")</script>`,
+			want: "<p>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;</p>\n",
+		},
+		{
+			name: "backslash escapes",
+			src:  `\*pas d'italique\*`,
+			want: "<p>*pas d&#39;italique*</p>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(Render(tt.src)); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender_NoUnsafeAttributes(t *testing.T) {
+	for _, src := range []string{
+		`[x](https://a.com"onmouseover="alert(1))`,
+		`[x](//evil.com)`,
+		`[x](data:text/html;base64,AAAA)`,
+	} {
+		got := string(Render(src))
+		if strings.Contains(got, `"onmouseover`) || strings.Contains(got, "evil.com\"") || strings.Contains(got, `href="data:`) {
+			t.Errorf("Render(%q) = %q, contains unsafe markup", src, got)
+		}
+	}
+}